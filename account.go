@@ -0,0 +1,102 @@
+package rpcx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// maxBalanceQueries bounds the balance queries GetBalances runs at a time.
+const maxBalanceQueries = 16
+
+// GetAccount returns the account state of address. An account unknown to
+// BitXHub is returned with a zero balance.
+func (cli *ChainClient) GetAccount(address string) (*Account, error) {
+	return cli.GetAccountCtx(context.Background(), address)
+}
+
+func (cli *ChainClient) GetAccountCtx(ctx context.Context, address string) (*Account, error) {
+	res, err := cli.GetAccountBalanceCtx(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{}
+	if err := json.Unmarshal(res.Data, account); err != nil {
+		return nil, fmt.Errorf("unmarshal account %s: %w", address, err)
+	}
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	return account, nil
+}
+
+func (cli *ChainClient) GetBalance(address string) (*big.Int, error) {
+	return cli.GetBalanceCtx(context.Background(), address)
+}
+
+func (cli *ChainClient) GetBalanceCtx(ctx context.Context, address string) (*big.Int, error) {
+	account, err := cli.GetAccountCtx(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return account.Balance, nil
+}
+
+// GetBalances returns the balances of addresses, querying them concurrently
+// over the nodes of the pool. It fails if any of the balances can't be got.
+func (cli *ChainClient) GetBalances(addresses []string) (map[string]*big.Int, error) {
+	return cli.GetBalancesCtx(context.Background(), addresses)
+}
+
+func (cli *ChainClient) GetBalancesCtx(ctx context.Context, addresses []string) (map[string]*big.Int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		balances = make(map[string]*big.Int, len(addresses))
+		sem      = make(chan struct{}, maxBalanceQueries)
+	)
+	for _, address := range addresses {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(address string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			balance, err := cli.GetBalanceCtx(ctx, address)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("get balance of %s: %w", address, err)
+					cancel()
+				}
+				return
+			}
+			balances[address] = balance
+		}(address)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
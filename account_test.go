@@ -0,0 +1,69 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// balanceBroker fails the balance query of any address from balanceAddr(100)
+// on.
+type balanceBroker struct {
+	*rpcxtest.Broker
+}
+
+func balanceAddr(i int) string {
+	return fmt.Sprintf("0x%040x", i)
+}
+
+func (b *balanceBroker) GetAccountBalance(ctx context.Context, addr *pb.Address) (*pb.Response, error) {
+	var i int
+	if _, err := fmt.Sscanf(addr.Address, "0x%x", &i); err != nil || i >= 100 {
+		return nil, status.Error(codes.Internal, "invalid address")
+	}
+	return b.Broker.GetAccountBalance(ctx, addr)
+}
+
+func TestChainClient_GetBalances(t *testing.T) {
+	broker := rpcxtest.NewBroker()
+	require.Nil(t, broker.StartWith(&balanceBroker{Broker: broker}))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker})
+	// the i-th address of balanceAddr holds i tokens
+	for i := 1; i < 50; i++ {
+		broker.SetBalance(types.NewAddressByStr(balanceAddr(i)), new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(1e18)))
+	}
+
+	account, err := cli.GetAccount(balanceAddr(0))
+	require.Nil(t, err)
+	require.Equal(t, "normal", account.Type)
+	require.Equal(t, 0, account.Balance.Sign())
+
+	balance, err := cli.GetBalance(balanceAddr(1))
+	require.Nil(t, err)
+	require.Equal(t, "1000000000000000000", balance.String())
+
+	var addresses []string
+	for i := 0; i < 50; i++ {
+		addresses = append(addresses, balanceAddr(i))
+	}
+	balances, err := cli.GetBalances(addresses)
+	require.Nil(t, err)
+	require.Len(t, balances, 50)
+	for i, address := range addresses {
+		expected := new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(1e18))
+		require.Equal(t, 0, expected.Cmp(balances[address]), address)
+	}
+
+	_, err = cli.GetBalances(append(addresses, balanceAddr(100)))
+	require.ErrorIs(t, err, ErrBrokenNetwork)
+	require.Contains(t, err.Error(), balanceAddr(100))
+}
@@ -2,7 +2,6 @@ package rpcx
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/meshplus/bitxhub-model/pb"
@@ -16,86 +15,59 @@ const (
 )
 
 func (cli *ChainClient) GetBlocks(start uint64, end uint64, fullTx bool) (*pb.GetBlocksResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetBlocksTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetBlocksCtx(context.Background(), start, end, fullTx)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
+func (cli *ChainClient) GetBlocksCtx(ctx context.Context, start uint64, end uint64, fullTx bool) (*pb.GetBlocksResponse, error) {
 	request := &pb.GetBlocksRequest{
 		Start:  start,
 		End:    end,
 		FullTx: fullTx,
 	}
-	response, err := grpcClient.broker.GetBlocks(ctx, request)
+	var response *pb.GetBlocksResponse
+	err := cli.call(ctx, "GetBlocks", GetBlocksTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetBlocks(ctx, request)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
 	return response, nil
 }
 
 func (cli *ChainClient) GetBlock(value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetBlockTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetBlockCtx(context.Background(), value, blockType, fullTx)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
+func (cli *ChainClient) GetBlockCtx(ctx context.Context, value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error) {
 	request := &pb.GetBlockRequest{
 		Type:   blockType,
 		Value:  value,
 		FullTx: fullTx,
 	}
-	response, err := grpcClient.broker.GetBlock(ctx, request)
+	var response *pb.Block
+	err := cli.call(ctx, "GetBlock", GetBlockTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetBlock(ctx, request)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
 	return response, nil
 }
 
 func (cli *ChainClient) GetChainStatus() (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetInfoTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetChainStatusCtx(context.Background())
+}
 
-	grpcClient, err := cli.pool.getClient()
+func (cli *ChainClient) GetChainStatusCtx(ctx context.Context) (*pb.Response, error) {
+	var response *pb.Response
+	err := cli.call(ctx, "GetInfo", GetInfoTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetInfo(ctx, &pb.Request{Type: pb.Request_CHAIN_STATUS})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetInfo(ctx, &pb.Request{Type: pb.Request_CHAIN_STATUS})
-	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
 	return response, nil
 }
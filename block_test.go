@@ -1,6 +1,7 @@
 package rpcx
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 	"time"
@@ -19,6 +20,21 @@ func TestChainClient_GetBlock(t *testing.T) {
 	require.NotNil(t, block)
 }
 
+func TestChainClient_GetBlockCtx(t *testing.T) {
+	_, cli, _ := newBrokerClient(t, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), GetBlockTimeout)
+	defer cancel()
+	block, err := cli.GetBlockCtx(ctx, "", pb.GetBlockRequest_LATEST, false)
+	require.Nil(t, err)
+	require.NotNil(t, block)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = cli.GetBlockCtx(canceledCtx, "", pb.GetBlockRequest_LATEST, false)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestChainClient_GetChainStatus(t *testing.T) {
 	cli, err := Cli()
 	require.Nil(t, err)
@@ -0,0 +1,62 @@
+package rpcx
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// newBrokerClient connects a client with a new account to one node per broker,
+// or to a new broker if none is given, and returns the first broker. The
+// account is funded on every broker. A broker not served yet, like by
+// StartWith, is started and stopped with the test.
+func newBrokerClient(t testing.TB, brokers []*rpcxtest.Broker, opts ...Option) (*rpcxtest.Broker, *ChainClient, *types.Address) {
+	if len(brokers) == 0 {
+		brokers = []*rpcxtest.Broker{rpcxtest.NewBroker()}
+	}
+	privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	from, err := privKey.PublicKey().Address()
+	require.Nil(t, err)
+
+	nodesInfo := make([]*NodeInfo, 0, len(brokers))
+	for _, broker := range brokers {
+		if broker.Addr() == "" {
+			require.Nil(t, broker.Start())
+			t.Cleanup(broker.Stop)
+		}
+		broker.SetBalance(from, big.NewInt(100))
+		nodesInfo = append(nodesInfo, &NodeInfo{Addr: broker.Addr()})
+	}
+
+	cli, err := NewWithNoGlobalPool(append([]Option{
+		WithNodesInfo(nodesInfo...),
+		WithLogger(logrus.New()),
+		WithPrivateKey(privKey),
+		WithReceiptPollInterval(50 * time.Millisecond),
+		WithResubscribeBackoff(50*time.Millisecond, 200*time.Millisecond),
+	}, opts...)...)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = cli.Stop()
+	})
+	return brokers[0], cli, from
+}
+
+// sendTestTransfer sends a transfer from the account of cli, which commits a
+// new block.
+func sendTestTransfer(cli *ChainClient) (string, error) {
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	tx, err := NewTxBuilder(cli.currentSigner().Address()).Transfer(to, big.NewInt(1)).Build()
+	if err != nil {
+		return "", err
+	}
+	return cli.SendTransaction(tx, nil)
+}
@@ -2,82 +2,66 @@ package rpcx
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/meshplus/bitxhub-model/pb"
 )
 
 func (cli *ChainClient) CheckMasterPier(address string) (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), CheckPierTimeout)
-	defer cancel()
+	return cli.CheckMasterPierCtx(context.Background(), address)
+}
 
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
+func (cli *ChainClient) CheckMasterPierCtx(ctx context.Context, address string) (*pb.Response, error) {
+	request := &pb.Address{
+		Address: address,
 	}
-
-	grpcClient, err := cli.pool.getClient()
+	var response *pb.Response
+	err := cli.call(ctx, "CheckMasterPier", CheckPierTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.CheckMasterPier(ctx, request)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	request := &pb.Address{
-		Address: address,
-	}
-	return grpcClient.broker.CheckMasterPier(ctx, request)
+	return response, nil
 }
 
 func (cli *ChainClient) SetMasterPier(address string, index string, timeout int64) (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), CheckPierTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.SetMasterPierCtx(context.Background(), address, index, timeout)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
+func (cli *ChainClient) SetMasterPierCtx(ctx context.Context, address string, index string, timeout int64) (*pb.Response, error) {
 	request := &pb.PierInfo{
 		Address: address,
 		Index:   index,
 		Timeout: timeout,
 	}
-	return grpcClient.broker.SetMasterPier(ctx, request)
+	var response *pb.Response
+	err := cli.call(ctx, "SetMasterPier", CheckPierTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.SetMasterPier(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 func (cli *ChainClient) HeartBeat(address string, index string) (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), CheckPierTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.HeartBeatCtx(context.Background(), address, index)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
+func (cli *ChainClient) HeartBeatCtx(ctx context.Context, address string, index string) (*pb.Response, error) {
 	request := &pb.PierInfo{
 		Address: address,
 		Index:   index,
 	}
-	return grpcClient.broker.HeartBeat(ctx, request)
+	var response *pb.Response
+	err := cli.call(ctx, "HeartBeat", CheckPierTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.HeartBeat(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
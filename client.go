@@ -2,6 +2,7 @@ package rpcx
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/meshplus/bitxhub-kit/crypto"
 	"github.com/meshplus/bitxhub-kit/types"
@@ -16,12 +17,17 @@ const (
 
 //go:generate mockgen -destination mock_client/mock_client.go -package mock_client -source client.go
 type Client interface {
+	ContextClient
+
 	//Close all connections between BitXHub and the client.
 	Stop() error
 
-	//Reset ecdsa key.
+	//Reset ecdsa key. A key whose address can't be derived is logged and ignored.
 	SetPrivateKey(crypto.PrivateKey)
 
+	//Set the signer of transactions, which holds the key instead of the client.
+	SetSigner(Signer)
+
 	//Send a readonly transaction to BitXHub. If the transaction is writable,
 	// this transaction will not be executed and error wil be returned.
 	SendView(tx *pb.BxhTransaction) (*pb.Receipt, error)
@@ -39,10 +45,20 @@ type Client interface {
 
 	SendRawTransactionWithReceipt(tx *pb.BxhTransaction) (*pb.Receipt, error)
 
+	//Wrap a transaction with its nonce and the chain ID filled in, to be signed offline.
+	ExportUnsignedTx(tx *pb.BxhTransaction, opts *TransactOpts) (*TxEnvelope, error)
+
+	//Verify the transaction signed offline and send it to BitXHub.
+	SubmitTxEnvelope(env *TxEnvelope) (string, error)
+
 	//Get the receipt by transaction hash,
 	//the status of the receipt is a sign of whether the transaction is successful.
 	GetReceipt(hash string) (*pb.Receipt, error)
 
+	//Wait until the transaction is packed into a block and get its receipt,
+	//new blocks are followed through subscription instead of polling.
+	WaitForReceipt(hash string) (*pb.Receipt, error)
+
 	//Get transaction from BitXHub by transaction hash.
 	GetTransaction(hash string) (*pb.GetTransactionResponse, error)
 
@@ -73,18 +89,60 @@ type Client interface {
 	//Get account balance from BitXHub by address.
 	GetAccountBalance(address string) (*pb.Response, error)
 
+	//Get the decoded account state from BitXHub by address.
+	GetAccount(address string) (*Account, error)
+
+	//Get account balance from BitXHub by address.
+	GetBalance(address string) (*big.Int, error)
+
+	//Get the balances of many accounts concurrently, keyed by address.
+	GetBalances(addresses []string) (map[string]*big.Int, error)
+
 	//Get the missing block header from BitXHub.
 	GetBlockHeader(ctx context.Context, begin, end uint64, ch chan<- *pb.BlockHeader) error
 
-	//Get the missing block header from BitXHub.
+	//Get the missing interchain tx wrappers from BitXHub.
 	GetInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64, ch chan<- *pb.InterchainTxWrappers) error
 
+	//Get the missing interchain tx wrappers from BitXHub. Errors, like a wrapper
+	//failing verification, are delivered on the error channel.
+	SyncInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64) (<-chan *pb.InterchainTxWrappers, <-chan error)
+
+	//Verify the merkle proofs of wrappers against their block headers.
+	VerifyInterchainTxWrappers(wrappers *pb.InterchainTxWrappers) error
+
 	//Subscribe to event notifications from BitXHub.
 	Subscribe(context.Context, pb.SubscriptionRequest_Type, []byte) (<-chan interface{}, error)
 
 	//SubscribeAudit to event notifications from BitXHub with permission.
 	SubscribeAudit(context.Context, pb.AuditSubscriptionRequest_Type, uint64, []byte) (<-chan interface{}, error)
 
+	//Subscribe to new blocks from BitXHub. Errors are delivered on the error channel,
+	//after which both channels are closed.
+	SubscribeBlocks(ctx context.Context) (<-chan *pb.Block, <-chan error)
+
+	//Subscribe to new block headers from BitXHub.
+	SubscribeBlockHeaders(ctx context.Context) (<-chan *pb.BlockHeader, <-chan error)
+
+	//Subscribe to contract events from BitXHub.
+	SubscribeEvents(ctx context.Context) (<-chan *pb.Event, <-chan error)
+
+	//Subscribe to interchain transactions from BitXHub.
+	SubscribeInterchainTx(ctx context.Context) (<-chan *pb.IBTP, <-chan error)
+
+	//Subscribe to the interchain tx wrappers of an appchain from BitXHub.
+	SubscribeInterchainTxWrappers(ctx context.Context, pid string) (<-chan *pb.InterchainTxWrappers, <-chan error)
+
+	//Subscribe to the audit info of the client's audit node from BitXHub.
+	SubscribeAuditTxInfo(ctx context.Context, blockHeight uint64) (<-chan *pb.AuditTxInfo, <-chan error)
+
+	//Subscribe to blocks from height on. Broken streams are resumed and missed blocks
+	//are fetched, so every block is delivered once and in order.
+	SubscribeBlocksFrom(ctx context.Context, height uint64) <-chan *pb.Block
+
+	//Subscribe to block headers from height on, resuming across disconnections.
+	SubscribeBlockHeadersFrom(ctx context.Context, height uint64) <-chan *pb.BlockHeader
+
 	//Deploy the contract, the contract address will be returned when the deployment is successful.
 	DeployContract(contract []byte, opts *TransactOpts) (contractAddr *types.Address, err error)
 
@@ -94,6 +152,18 @@ type Client interface {
 	// GenerateIBTPTx generates interchain tx with ibtp specified
 	GenerateIBTPTx(ibtp *pb.IBTP) (*pb.BxhTransaction, error)
 
+	//Build the IBTP and send it in an interchain transaction.
+	SendIBTP(b *IBTPBuilder, opts *TransactOpts) (*pb.Receipt, error)
+
+	//Send the receipt of an interchain IBTP executed by its destination.
+	SendIBTPReceipt(ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *TransactOpts) (*pb.Receipt, error)
+
+	//Get the status of the interchain transaction of an IBTP.
+	GetIBTPStatus(id string) (pb.TransactionStatus, error)
+
+	//Wait until the interchain transaction of an IBTP succeeds, fails or is rolled back.
+	WaitIBTP(id string) (pb.TransactionStatus, error)
+
 	//Call the contract according to the contract type, contract address,
 	//contract method, and contract method parameters
 	InvokeContract(vmType pb.TransactionData_VMType, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error)
@@ -110,6 +180,49 @@ type Client interface {
 	// Get BitXHub's tss signatures specified by id and type.
 	GetTssSigns(id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error)
 
+	// Verify BitXHub's multi-signatures against its validators with a 2f+1 quorum.
+	VerifyMultiSigns(content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*MultiSignResult, error)
+
+	// Get the typed client of BitXHub's governance contracts.
+	Governance() Governance
+
+	// Get the client of BitXHub's governance proposals.
+	Proposals() Proposals
+
+	// Get the appchain registered with the given ID.
+	GetAppchain(id string) (*Appchain, error)
+
+	// List all appchains registered in BitXHub.
+	ListAppchains() ([]*Appchain, error)
+
+	// Get the service by its full ID, as "chainID:serviceID".
+	GetService(chainServiceID string) (*Service, error)
+
+	// List the services registered by an appchain.
+	ListServices(chainID string) ([]*Service, error)
+
+	// Get the BitXHub node registered with the given account.
+	GetNode(account string) (*Node, error)
+
+	// List all BitXHub nodes.
+	ListNodes() ([]*Node, error)
+
+	// Get the validation rule deployed at ruleAddr for an appchain.
+	GetRule(chainID, ruleAddr string) (*Rule, error)
+
+	// List the validation rules registered for an appchain.
+	ListRules(chainID string) ([]*Rule, error)
+
+	// Get the governance role with the given ID.
+	GetRole(id string) (*Role, error)
+
+	// Get the interchain counters of an appchain or service.
+	GetInterchain(id string) (*Interchain, error)
+
+	// Get the interchain counters of a service by its full ID, with empty counters
+	// for a service which never took part in an interchain.
+	GetInterchainMeta(serviceID string) (*Interchain, error)
+
 	// Get BitXHub TPS during block [begin, end]
 	GetTPS(begin, end uint64) (uint64, error)
 
@@ -138,8 +251,171 @@ type Client interface {
 	GetChainID() (uint64, error)
 }
 
+// ContextClient is the context-first variant of Client. Each method behaves like
+// its counterpart without the Ctx suffix, but it stops as soon as ctx is done and
+// passes ctx values to the grpc call. If ctx carries no deadline, the default
+// timeout of the counterpart is applied.
+type ContextClient interface {
+	SendViewCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error)
+
+	SendTransactionCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (string, error)
+
+	SendTransactionsCtx(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error)
+
+	SendRawTransactionCtx(ctx context.Context, tx *pb.BxhTransaction) (string, error)
+
+	SendTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (*pb.Receipt, error)
+
+	SendRawTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error)
+
+	ExportUnsignedTxCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (*TxEnvelope, error)
+
+	SubmitTxEnvelopeCtx(ctx context.Context, env *TxEnvelope) (string, error)
+
+	GetReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error)
+
+	WaitForReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error)
+
+	GetTransactionCtx(ctx context.Context, hash string) (*pb.GetTransactionResponse, error)
+
+	GetTransactionByBlockHashAndIndexCtx(ctx context.Context, blockHash string, index uint64) (*pb.GetTransactionResponse, error)
+
+	GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNum uint64, index uint64) (*pb.GetTransactionResponse, error)
+
+	GetChainMetaCtx(ctx context.Context) (*pb.ChainMeta, error)
+
+	GetBlocksCtx(ctx context.Context, start uint64, end uint64, fullTx bool) (*pb.GetBlocksResponse, error)
+
+	GetBlockCtx(ctx context.Context, value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error)
+
+	GetChainStatusCtx(ctx context.Context) (*pb.Response, error)
+
+	GetValidatorsCtx(ctx context.Context) (*pb.Response, error)
+
+	GetNetworkMetaCtx(ctx context.Context) (*pb.Response, error)
+
+	GetAccountBalanceCtx(ctx context.Context, address string) (*pb.Response, error)
+
+	GetAccountCtx(ctx context.Context, address string) (*Account, error)
+
+	GetBalanceCtx(ctx context.Context, address string) (*big.Int, error)
+
+	GetBalancesCtx(ctx context.Context, addresses []string) (map[string]*big.Int, error)
+
+	VerifyInterchainTxWrappersCtx(ctx context.Context, wrappers *pb.InterchainTxWrappers) error
+
+	DeployContractCtx(ctx context.Context, contract []byte, opts *TransactOpts) (contractAddr *types.Address, err error)
+
+	SendIBTPCtx(ctx context.Context, b *IBTPBuilder, opts *TransactOpts) (*pb.Receipt, error)
+
+	SendIBTPReceiptCtx(ctx context.Context, ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *TransactOpts) (*pb.Receipt, error)
+
+	GetIBTPStatusCtx(ctx context.Context, id string) (pb.TransactionStatus, error)
+
+	WaitIBTPCtx(ctx context.Context, id string) (pb.TransactionStatus, error)
+
+	InvokeContractCtx(ctx context.Context, vmType pb.TransactionData_VMType, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error)
+
+	InvokeBVMContractCtx(ctx context.Context, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error)
+
+	InvokeXVMContractCtx(ctx context.Context, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error)
+
+	GetMultiSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error)
+
+	GetTssSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error)
+
+	VerifyMultiSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*MultiSignResult, error)
+
+	GetAppchainCtx(ctx context.Context, id string) (*Appchain, error)
+
+	ListAppchainsCtx(ctx context.Context) ([]*Appchain, error)
+
+	GetServiceCtx(ctx context.Context, chainServiceID string) (*Service, error)
+
+	ListServicesCtx(ctx context.Context, chainID string) ([]*Service, error)
+
+	GetNodeCtx(ctx context.Context, account string) (*Node, error)
+
+	ListNodesCtx(ctx context.Context) ([]*Node, error)
+
+	GetRuleCtx(ctx context.Context, chainID, ruleAddr string) (*Rule, error)
+
+	ListRulesCtx(ctx context.Context, chainID string) ([]*Rule, error)
+
+	GetRoleCtx(ctx context.Context, id string) (*Role, error)
+
+	GetInterchainCtx(ctx context.Context, id string) (*Interchain, error)
+
+	GetInterchainMetaCtx(ctx context.Context, serviceID string) (*Interchain, error)
+
+	GetTPSCtx(ctx context.Context, begin, end uint64) (uint64, error)
+
+	GetPendingNonceByAccountCtx(ctx context.Context, account string) (uint64, error)
+
+	CheckMasterPierCtx(ctx context.Context, address string) (*pb.Response, error)
+
+	SetMasterPierCtx(ctx context.Context, address string, index string, timeout int64) (*pb.Response, error)
+
+	HeartBeatCtx(ctx context.Context, address string, index string) (*pb.Response, error)
+
+	GetChainIDCtx(ctx context.Context) (uint64, error)
+}
+
+// Governance is the typed client of the governance methods of the appchain,
+// service, node and rule manager contracts of BitXHub. Methods starting a
+// proposal return its ID.
+type Governance interface {
+	RegisterAppchain(ctx context.Context, req *RegisterAppchainRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	UpdateAppchain(ctx context.Context, req *UpdateAppchainRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	FreezeAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	ActivateAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	LogoutAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	RegisterService(ctx context.Context, req *RegisterServiceRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	UpdateService(ctx context.Context, req *UpdateServiceRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	FreezeService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	ActivateService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	LogoutService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	RegisterNode(ctx context.Context, req *RegisterNodeRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	LogoutNode(ctx context.Context, nodeAccount, reason string, opts *TransactOpts) (*GovernanceResult, error)
+
+	RegisterRule(ctx context.Context, req *RegisterRuleRequest, opts *TransactOpts) (*GovernanceResult, error)
+
+	UpdateMasterRule(ctx context.Context, chainID, ruleAddr, reason string, opts *TransactOpts) (*GovernanceResult, error)
+}
+
+// Proposals queries and drives the proposals of the BitXHub governance contract.
+type Proposals interface {
+	//Get the proposal with the given ID.
+	Get(ctx context.Context, id string) (*Proposal, error)
+
+	//List the proposals matching filter.
+	List(ctx context.Context, filter ProposalFilter) ([]*Proposal, error)
+
+	//Approve or reject the proposal on behalf of the client's account.
+	Vote(ctx context.Context, id string, approve bool, reason string, opts *TransactOpts) (*pb.Receipt, error)
+
+	//Withdraw a proposal submitted by the client's account.
+	Withdraw(ctx context.Context, id, reason string, opts *TransactOpts) (*pb.Receipt, error)
+
+	//Wait until the proposal is approved or rejected.
+	WaitProposal(ctx context.Context, id string) (*Proposal, error)
+}
+
 type TransactOpts struct {
 	From    string
 	Nonce   uint64
 	PrivKey crypto.PrivateKey
+	// Signer signs the transaction instead of PrivKey or the signer of the client
+	Signer Signer
 }
@@ -7,21 +7,51 @@ import (
 	"github.com/meshplus/bitxhub-kit/crypto"
 	"github.com/meshplus/bitxhub-kit/fileutil"
 	"github.com/meshplus/bitxhub-kit/log"
+	"github.com/meshplus/bitxhub-model/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 const (
 	blockChanNumber = 1024
 	defaultTimeout  = 1 * time.Second
-	defaultPoolSize = 4
+	defaultPoolSize = 1
+
+	// keep the ping interval above the default minimum the grpc server enforces
+	defaultKeepaliveTime    = 5 * time.Minute
+	defaultKeepaliveTimeout = 20 * time.Second
 )
 
 type config struct {
 	logger       Logger
 	poolSize     int
 	privateKey   crypto.PrivateKey
+	signer       Signer
 	nodesInfo    []*NodeInfo
 	ipfsAddrs    []string
 	timeoutLimit time.Duration // timeout limit config for dialing grpc
+	keepalive    keepalive.ClientParameters
+
+	nodeSelector    NodeSelector
+	maxFailures     uint64        // consecutive failures before a node is ejected
+	ejectBackoff    time.Duration // initial duration a node stays ejected
+	maxEjectBackoff time.Duration // upper bound of the ejection duration
+
+	nonceManager        bool
+	verifyWrappers      bool
+	signDigests         map[pb.GetSignsRequest_Type]SignDigestFunc
+	receiptPollInterval time.Duration // interval of receipt polling when block subscription fails
+
+	resubscribeBackoff    time.Duration // initial delay before a broken subscription is resumed
+	maxResubscribeBackoff time.Duration // upper bound of the resubscribe delay
+
+	retryPolicy *RetryPolicy
+
+	interceptors       []Interceptor
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+
+	metrics Metrics
 }
 
 type NodeInfo struct {
@@ -53,6 +83,14 @@ func WithPrivateKey(key crypto.PrivateKey) Option {
 	}
 }
 
+// WithSigner lets signer sign the transactions of the client in place of a
+// private key, which then never needs to be held by the client.
+func WithSigner(signer Signer) Option {
+	return func(config *config) {
+		config.signer = signer
+	}
+}
+
 func WithIPFSInfo(addrs []string) Option {
 	return func(config *config) {
 		config.ipfsAddrs = addrs
@@ -65,12 +103,127 @@ func WithTimeoutLimit(limit time.Duration) Option {
 	}
 }
 
+// WithPoolSize sets the number of long-lived grpc channels kept to each node.
+// Every channel multiplexes concurrent calls, so one is usually enough, which
+// is the default if size is 0.
 func WithPoolSize(size int) Option {
 	return func(config *config) {
 		config.poolSize = size
 	}
 }
 
+// WithNonceManager lets the client assign nonces from a local per-account cache
+// instead of querying BitXHub before every transaction without a nonce.
+func WithNonceManager() Option {
+	return func(config *config) {
+		config.nonceManager = true
+	}
+}
+
+// WithWrapperVerification makes the client verify the merkle proofs of interchain
+// tx wrappers against their block headers before delivering them.
+func WithWrapperVerification() Option {
+	return func(config *config) {
+		config.verifyWrappers = true
+	}
+}
+
+// WithSignDigest sets how the digest signed by validators is rebuilt for a sign type
+// when verifying multi-signatures, overriding the built-in one if any.
+func WithSignDigest(typ pb.GetSignsRequest_Type, digest SignDigestFunc) Option {
+	return func(config *config) {
+		if config.signDigests == nil {
+			config.signDigests = make(map[pb.GetSignsRequest_Type]SignDigestFunc)
+		}
+		config.signDigests[typ] = digest
+	}
+}
+
+// WithReceiptPollInterval sets how often the receipt is queried while waiting
+// for it without a block subscription.
+func WithReceiptPollInterval(interval time.Duration) Option {
+	return func(config *config) {
+		config.receiptPollInterval = interval
+	}
+}
+
+// WithResubscribeBackoff sets the delay before a broken resumable subscription
+// reconnects. The delay doubles on every failed attempt up to maxBackoff.
+func WithResubscribeBackoff(backoff, maxBackoff time.Duration) Option {
+	return func(config *config) {
+		config.resubscribeBackoff = backoff
+		config.maxResubscribeBackoff = maxBackoff
+	}
+}
+
+// WithRetryPolicy sets how the calls to BitXHub are retried, DefaultRetryPolicy
+// if not set. Use RetryPolicy{MaxAttempts: 1} to never retry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(config *config) {
+		config.retryPolicy = &policy
+	}
+}
+
+// WithInterceptors adds interceptors of the calls of the client to BitXHub,
+// called in order, the first one outermost.
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(config *config) {
+		config.interceptors = append(config.interceptors, interceptors...)
+	}
+}
+
+// WithUnaryInterceptors adds grpc interceptors of the unary calls to the nodes,
+// passed to dialing. A client made by New shares the channels of the first
+// client, and so its grpc interceptors.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(config *config) {
+		config.unaryInterceptors = append(config.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds grpc interceptors of the streams to the nodes,
+// passed to dialing like WithUnaryInterceptors.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(config *config) {
+		config.streamInterceptors = append(config.streamInterceptors, interceptors...)
+	}
+}
+
+// WithMetrics makes the client report its calls, connections, subscriptions
+// and receipt waits to metrics. A client made by New shares the connection pool
+// of the first client, which reports to the metrics of that client.
+func WithMetrics(metrics Metrics) Option {
+	return func(config *config) {
+		config.metrics = metrics
+	}
+}
+
+// WithKeepaliveParams sets the keepalive parameters of the grpc channels.
+func WithKeepaliveParams(params keepalive.ClientParameters) Option {
+	return func(config *config) {
+		config.keepalive = params
+	}
+}
+
+// WithNodeSelector sets the strategy used to choose a node among the healthy ones.
+func WithNodeSelector(selector NodeSelector) Option {
+	return func(config *config) {
+		config.nodeSelector = selector
+	}
+}
+
+// WithNodeEjection ejects a node after maxFailures consecutive failures. The node
+// is re-probed after backoff, which doubles on every new ejection up to maxBackoff.
+// If every node is ejected, calls go to the node re-probed first without
+// waiting for its backoff.
+func WithNodeEjection(maxFailures uint64, backoff, maxBackoff time.Duration) Option {
+	return func(config *config) {
+		config.maxFailures = maxFailures
+		config.ejectBackoff = backoff
+		config.maxEjectBackoff = maxBackoff
+	}
+}
+
 func generateConfig(opts ...Option) (*config, error) {
 	config := &config{}
 	for _, opt := range opts {
@@ -85,8 +238,15 @@ func generateConfig(opts ...Option) (*config, error) {
 }
 
 func checkConfig(config *config) error {
-	if config.privateKey == nil {
-		return fmt.Errorf("private key is empty")
+	if config.signer == nil {
+		if config.privateKey == nil {
+			return fmt.Errorf("private key is empty")
+		}
+		signer, err := NewKeySigner(config.privateKey)
+		if err != nil {
+			return err
+		}
+		config.signer = signer
 	}
 
 	if len(config.nodesInfo) == 0 {
@@ -101,10 +261,65 @@ func checkConfig(config *config) error {
 		config.timeoutLimit = defaultTimeout
 	}
 
+	if config.poolSize < 0 {
+		return fmt.Errorf("pool size %d is negative", config.poolSize)
+	}
 	if config.poolSize == 0 {
 		config.poolSize = defaultPoolSize
 	}
 
+	if config.receiptPollInterval == 0 {
+		config.receiptPollInterval = defaultReceiptPollInterval
+	}
+
+	if config.resubscribeBackoff == 0 {
+		config.resubscribeBackoff = defaultResubscribeBackoff
+	}
+
+	if config.maxResubscribeBackoff < config.resubscribeBackoff {
+		config.maxResubscribeBackoff = defaultMaxResubscribeBackoff
+		if config.maxResubscribeBackoff < config.resubscribeBackoff {
+			config.maxResubscribeBackoff = config.resubscribeBackoff
+		}
+	}
+
+	if config.retryPolicy == nil {
+		policy := DefaultRetryPolicy()
+		config.retryPolicy = &policy
+	}
+	config.retryPolicy.normalize()
+
+	if config.metrics == nil {
+		config.metrics = nopMetrics{}
+	}
+
+	if config.keepalive.Time == 0 {
+		config.keepalive.Time = defaultKeepaliveTime
+	}
+
+	if config.keepalive.Timeout == 0 {
+		config.keepalive.Timeout = defaultKeepaliveTimeout
+	}
+
+	if config.nodeSelector == nil {
+		config.nodeSelector = RandomSelector()
+	}
+
+	if config.maxFailures == 0 {
+		config.maxFailures = defaultMaxFailures
+	}
+
+	if config.ejectBackoff == 0 {
+		config.ejectBackoff = defaultEjectBackoff
+	}
+
+	if config.maxEjectBackoff < config.ejectBackoff {
+		config.maxEjectBackoff = defaultMaxEjectBackoff
+		if config.maxEjectBackoff < config.ejectBackoff {
+			config.maxEjectBackoff = config.ejectBackoff
+		}
+	}
+
 	// if EnableTLS is set, then tls certs must be provided
 	for _, nodeInfo := range config.nodesInfo {
 		if nodeInfo.EnableTLS {
@@ -1,44 +1,29 @@
 package rpcx
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/meshplus/bitxhub-kit/types"
 	"github.com/meshplus/bitxhub-model/constant"
 	"github.com/meshplus/bitxhub-model/pb"
+	"google.golang.org/grpc/codes"
 )
 
 // DeployContract let client deploy the wasm contract into BitXHub.
 func (cli *ChainClient) DeployContract(contract []byte, opts *TransactOpts) (contractAddr *types.Address, err error) {
-	if len(contract) == 0 {
-		return nil, fmt.Errorf("can't deploy empty contract")
-	}
-
-	from, err := cli.privateKey.PublicKey().Address()
-	if err != nil {
-		return nil, err
-	}
-
-	td := &pb.TransactionData{
-		Type:    pb.TransactionData_INVOKE,
-		VmType:  pb.TransactionData_XVM,
-		Payload: contract,
-	}
+	return cli.DeployContractCtx(context.Background(), contract, opts)
+}
 
-	payload, err := td.Marshal()
+func (cli *ChainClient) DeployContractCtx(ctx context.Context, contract []byte, opts *TransactOpts) (contractAddr *types.Address, err error) {
+	tx, err := NewTxBuilder(cli.currentSigner().Address()).Deploy(contract).Build()
 	if err != nil {
 		return nil, err
 	}
 
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        &types.Address{},
-		Payload:   payload,
-		Timestamp: time.Now().UnixNano(),
-	}
-
-	receipt, err := cli.sendTransactionWithReceipt(tx, opts)
+	receipt, err := cli.sendTransactionWithReceipt(ctx, tx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -54,109 +39,92 @@ func (cli *ChainClient) DeployContract(contract []byte, opts *TransactOpts) (con
 // InvokeContract let client invoke the wasm contract with specific method.
 func (cli *ChainClient) InvokeContract(vmType pb.TransactionData_VMType, address *types.Address, method string,
 	opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
-	pk := cli.privateKey
-	if opts != nil {
-		if opts.PrivKey != nil {
-			pk = opts.PrivKey
-			addr, _ := pk.PublicKey().Address()
-			opts.From = addr.String()
-		}
-	}
-	from, err := pk.PublicKey().Address()
-	if err != nil {
-		return nil, err
-	}
-
-	pl := &pb.InvokePayload{
-		Method: method,
-		Args:   args[:],
-	}
+	return cli.InvokeContractCtx(context.Background(), vmType, address, method, opts, args...)
+}
 
-	data, err := pl.Marshal()
+func (cli *ChainClient) InvokeContractCtx(ctx context.Context, vmType pb.TransactionData_VMType, address *types.Address, method string,
+	opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	signer, err := cli.signerOf(opts)
 	if err != nil {
 		return nil, err
 	}
-
-	td := &pb.TransactionData{
-		Type:    pb.TransactionData_INVOKE,
-		VmType:  vmType,
-		Payload: data,
+	if opts != nil && (opts.Signer != nil || opts.PrivKey != nil) {
+		opts.From = signer.Address().String()
 	}
 
-	payload, err := td.Marshal()
+	tx, err := NewTxBuilder(signer.Address()).Invoke(vmType, address, method, args...).Build()
 	if err != nil {
 		return nil, err
 	}
 
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        address,
-		Payload:   payload,
-		Timestamp: time.Now().UnixNano(),
-	}
-
-	return cli.sendTransactionWithReceipt(tx, opts)
+	return cli.sendTransactionWithReceipt(ctx, tx, opts)
 }
 
 func (cli *ChainClient) InvokeBVMContract(address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
 	return cli.InvokeContract(pb.TransactionData_BVM, address, method, opts, args...)
 }
+
+func (cli *ChainClient) InvokeBVMContractCtx(ctx context.Context, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	return cli.InvokeContractCtx(ctx, pb.TransactionData_BVM, address, method, opts, args...)
+}
+
 func (cli *ChainClient) InvokeXVMContract(address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
 	return cli.InvokeContract(pb.TransactionData_XVM, address, method, opts, args...)
 }
 
-func (cli *ChainClient) GenerateIBTPTx(ibtp *pb.IBTP) (*pb.BxhTransaction, error) {
-	if ibtp == nil {
-		return nil, fmt.Errorf("empty ibtp not allowed")
-	}
-	from, err := cli.privateKey.PublicKey().Address()
+func (cli *ChainClient) InvokeXVMContractCtx(ctx context.Context, address *types.Address, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	return cli.InvokeContractCtx(ctx, pb.TransactionData_XVM, address, method, opts, args...)
+}
+
+// invokeBVM invokes a built-in contract and fails if the transaction is reverted.
+func (cli *ChainClient) invokeBVM(ctx context.Context, contract constant.BoltContractAddress, method string, opts *TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	receipt, err := cli.InvokeBVMContractCtx(ctx, contract.Address(), method, opts, args...)
 	if err != nil {
 		return nil, err
 	}
-
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        constant.InterchainContractAddr.Address(),
-		IBTP:      ibtp,
-		Timestamp: time.Now().UnixNano(),
+	if !receipt.IsSuccess() {
+		return nil, fmt.Errorf("%s failed: %s", method, string(receipt.Ret))
 	}
-
-	return tx, nil
+	return receipt, nil
 }
 
-func (cli *ChainClient) GenerateContractTx(vmType pb.TransactionData_VMType, address *types.Address, method string, args ...*pb.Arg) (*pb.BxhTransaction, error) {
-	from, err := cli.privateKey.PublicKey().Address()
+// viewBVM calls a read-only method of a built-in contract and returns its result.
+func (cli *ChainClient) viewBVM(ctx context.Context, contract constant.BoltContractAddress, method string, args ...*pb.Arg) ([]byte, error) {
+	tx, err := cli.GenerateContractTx(pb.TransactionData_BVM, contract.Address(), method, args...)
 	if err != nil {
 		return nil, err
 	}
-
-	pl := &pb.InvokePayload{
-		Method: method,
-		Args:   args[:],
-	}
-
-	data, err := pl.Marshal()
+	receipt, err := cli.SendViewCtx(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
-
-	td := &pb.TransactionData{
-		Type:    pb.TransactionData_INVOKE,
-		VmType:  vmType,
-		Payload: data,
+	if !receipt.IsSuccess() {
+		// the ret of a reverted view is classified like the message of a status
+		if kind := errorKind(codes.OK, strings.ToLower(string(receipt.Ret))); kind != nil {
+			return nil, fmt.Errorf("%w: %s failed: %s", kind, method, string(receipt.Ret))
+		}
+		return nil, fmt.Errorf("%s failed: %s", method, string(receipt.Ret))
 	}
+	return receipt.Ret, nil
+}
 
-	payload, err := td.Marshal()
+// queryBVM calls a read-only method of a built-in contract and decodes its JSON
+// result into ret.
+func (cli *ChainClient) queryBVM(ctx context.Context, contract constant.BoltContractAddress, method string, ret interface{}, args ...*pb.Arg) error {
+	data, err := cli.viewBVM(ctx, contract, method, args...)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        address,
-		Payload:   payload,
-		Timestamp: time.Now().UnixNano(),
+	if err := json.Unmarshal(data, ret); err != nil {
+		return fmt.Errorf("unmarshal %s result: %w", method, err)
 	}
+	return nil
+}
 
-	return tx, nil
+func (cli *ChainClient) GenerateIBTPTx(ibtp *pb.IBTP) (*pb.BxhTransaction, error) {
+	return NewTxBuilder(cli.currentSigner().Address()).IBTP(ibtp).Build()
+}
+
+func (cli *ChainClient) GenerateContractTx(vmType pb.TransactionData_VMType, address *types.Address, method string, args ...*pb.Arg) (*pb.BxhTransaction, error) {
+	return NewTxBuilder(cli.currentSigner().Address()).Invoke(vmType, address, method, args...).Build()
 }
@@ -1,8 +1,13 @@
 package rpcx
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -20,4 +25,165 @@ var (
 
 	// network problem received from grpc
 	ErrBrokenNetwork = fmt.Errorf("%w: grpc broker error", ErrRecoverable)
+
+	// the queried object doesn't exist on BitXHub
+	ErrNotFound = errors.New("not found")
+
+	// the nonce of the tx is already used by the account, the tx should be
+	// rebuilt with the pending nonce
+	ErrNonceTooLow = fmt.Errorf("%w: nonce too low", ErrReconstruct)
+
+	// the account can't pay for the tx
+	ErrInsufficientBalance = errors.New("insufficient balance")
+
+	// the account isn't allowed to do what is requested
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// the node didn't answer in time, which may be fixed by retrying
+	ErrTimeout = fmt.Errorf("%w: timeout", ErrBrokenNetwork)
 )
+
+// RPCError is an error returned by a BitXHub node for a call of the client,
+// keeping the gRPC status of the node. It matches with errors.Is the sentinel
+// of the logical error reported by the node, such as ErrNotFound or
+// ErrNonceTooLow, and ErrReconstruct for invalid requests or ErrBrokenNetwork
+// for failures of the node itself:
+//
+//	var rpcErr *RPCError
+//	if errors.As(err, &rpcErr) {
+//		log.Printf("%s failed on %s with %s", rpcErr.Method, rpcErr.Node, rpcErr.Code)
+//	}
+//	if errors.Is(err, ErrNonceTooLow) {
+//		// resend with the pending nonce
+//	}
+type RPCError struct {
+	// Method is the name of the called ChainBroker method, like "GetReceipt".
+	Method string
+	// Node is the address of the node.
+	Node    string
+	Code    codes.Code
+	Message string
+
+	// kind is the logical error, and class the one implied by the code
+	kind  error
+	class error
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc %s on node %s: code = %s desc = %s", e.Method, e.Node, e.Code, e.Message)
+}
+
+func (e *RPCError) Unwrap() error {
+	return e.kind
+}
+
+func (e *RPCError) Is(target error) bool {
+	return e.class != nil && errors.Is(e.class, target)
+}
+
+// GRPCStatus lets status.Code and status.Convert see the status of the node.
+func (e *RPCError) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// newRPCError classifies the error of the call of method on node. Errors not
+// coming from the call, like those of the client itself, are returned as is.
+func newRPCError(method, node string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+		if st.Code() == codes.Unknown {
+			return err
+		}
+	}
+
+	rpcErr = &RPCError{
+		Method:  method,
+		Node:    node,
+		Code:    st.Code(),
+		Message: st.Message(),
+	}
+	rpcErr.kind = errorKind(rpcErr.Code, strings.ToLower(rpcErr.Message))
+	switch {
+	case rpcErr.Code == codes.Canceled:
+		rpcErr.class = context.Canceled
+	case rpcErr.Code == codes.InvalidArgument:
+		rpcErr.class = ErrReconstruct
+	case rpcErr.kind != nil:
+		// a logical error reported by the node is no network failure
+	case isTransportCode(rpcErr.Code):
+		rpcErr.class = ErrBrokenNetwork
+	default:
+		// the node rejected the request for a reason unknown to the client,
+		// which retrying the same request won't fix
+		rpcErr.class = ErrReconstruct
+	}
+	return rpcErr
+}
+
+// isTransportCode tells whether code is reported by grpc or by the node for a
+// failure of the node itself or of the connection to it.
+func isTransportCode(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable, codes.Internal, codes.ResourceExhausted, codes.Aborted, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// nonceTooLowMessages are the messages of BitXHub for a tx whose nonce is used
+// already by its account. A nonce too high isn't rejected, as the tx pool of
+// BitXHub keeps the tx until the nonces before it are filled.
+var nonceTooLowMessages = []string{
+	"nonce too low",
+	"nonce is too low",
+	"invalid nonce",
+	"nonce is lower than",
+	"nonce has been used",
+}
+
+// contextError is the error of a call given up as ctx is done, after its last
+// attempt failed with err.
+func contextError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s", ErrTimeout, err)
+	}
+	return fmt.Errorf("%w: %s", ctx.Err(), err)
+}
+
+// errorKind tells the logical error from the code and the lower cased message
+// of a status, as BitXHub reports most of them with codes.Unknown.
+func errorKind(code codes.Code, msg string) error {
+	contains := func(substrs ...string) bool {
+		for _, substr := range substrs {
+			if strings.Contains(msg, substr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case code == codes.DeadlineExceeded:
+		return ErrTimeout
+	case code == codes.PermissionDenied || code == codes.Unauthenticated ||
+		contains("permission denied", "no permission", "not authorized", "unauthorized"):
+		return ErrPermissionDenied
+	case contains(nonceTooLowMessages...):
+		return ErrNonceTooLow
+	case contains("insufficient balance", "insufficient funds", "not sufficient funds"):
+		return ErrInsufficientBalance
+	case code == codes.NotFound || contains("not found", "not exist", "doesn't exist"):
+		return ErrNotFound
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewRPCError(t *testing.T) {
+	tests := []struct {
+		err     error
+		is      []error
+		isNot   []error
+		keepErr bool
+	}{
+		{
+			err:   status.Error(codes.Unavailable, "connection refused"),
+			is:    []error{ErrBrokenNetwork, ErrRecoverable},
+			isNot: []error{ErrNotFound, ErrReconstruct},
+		},
+		{
+			err:   status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			is:    []error{ErrTimeout, ErrBrokenNetwork},
+			isNot: []error{ErrNotFound},
+		},
+		{
+			err: context.DeadlineExceeded,
+			is:  []error{ErrTimeout, ErrBrokenNetwork},
+		},
+		{
+			err:   context.Canceled,
+			is:    []error{context.Canceled},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.InvalidArgument, "invalid signature"),
+			is:    []error{ErrReconstruct},
+			isNot: []error{ErrBrokenNetwork, ErrNonceTooLow},
+		},
+		{
+			err:   status.Error(codes.InvalidArgument, "invalid nonce 1, expect 3"),
+			is:    []error{ErrNonceTooLow, ErrReconstruct},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.InvalidArgument, "nonce too high: 5, expected 3"),
+			is:    []error{ErrReconstruct},
+			isNot: []error{ErrNonceTooLow},
+		},
+		{
+			err:   status.Error(codes.Unknown, "get nonce of account 0x12: db closed"),
+			is:    []error{ErrReconstruct},
+			isNot: []error{ErrNonceTooLow, ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.FailedPrecondition, "appchain 0x12 is frozen"),
+			is:    []error{ErrReconstruct},
+			isNot: []error{ErrBrokenNetwork, ErrRecoverable},
+		},
+		{
+			err:   status.Error(codes.Internal, "stream terminated by RST_STREAM"),
+			is:    []error{ErrBrokenNetwork},
+			isNot: []error{ErrReconstruct},
+		},
+		{
+			err:   status.Error(codes.Unknown, "receipt 0x12 not found in DB"),
+			is:    []error{ErrNotFound},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.NotFound, "no such block"),
+			is:    []error{ErrNotFound},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.Internal, "not sufficient funds for 0x12"),
+			is:    []error{ErrInsufficientBalance},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.PermissionDenied, "admin only"),
+			is:    []error{ErrPermissionDenied},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:   status.Error(codes.Unknown, "current caller 0x12 is not authorized"),
+			is:    []error{ErrPermissionDenied},
+			isNot: []error{ErrBrokenNetwork},
+		},
+		{
+			err:     fmt.Errorf("marshal request: %w", errors.New("bad field")),
+			isNot:   []error{ErrBrokenNetwork},
+			keepErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := newRPCError("GetReceipt", "localhost:60011", test.err)
+		for _, target := range test.is {
+			require.ErrorIs(t, err, target, test.err.Error())
+		}
+		for _, target := range test.isNot {
+			require.False(t, errors.Is(err, target), "%s is %s", test.err, target)
+		}
+		if test.keepErr {
+			require.Equal(t, test.err, err)
+			continue
+		}
+
+		var rpcErr *RPCError
+		require.True(t, errors.As(err, &rpcErr))
+		require.Equal(t, "GetReceipt", rpcErr.Method)
+		require.Equal(t, "localhost:60011", rpcErr.Node)
+		require.Equal(t, status.Code(err), rpcErr.Code)
+
+		// an error classified already keeps its method and node
+		var again *RPCError
+		require.True(t, errors.As(newRPCError("GetBlock", "localhost:60012", fmt.Errorf("wrapped: %w", err)), &again))
+		require.Equal(t, rpcErr, again)
+	}
+
+	require.Nil(t, newRPCError("GetReceipt", "localhost:60011", nil))
+}
+
+func TestChainClient_SendTransactionNonceError(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+
+	// the failure to get the nonce keeps its class
+	broker.InjectFault("GetPendingNonceByAccount", rpcxtest.Fault{Code: codes.PermissionDenied, Times: 1})
+	_, err := sendTestTransfer(cli)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.False(t, errors.Is(err, ErrBrokenNetwork))
+	var rpcErr *RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, "GetPendingNonceByAccount", rpcErr.Method)
+}
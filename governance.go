@@ -0,0 +1,218 @@
+package rpcx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// GovernanceResult is returned by governance methods which start a proposal.
+type GovernanceResult struct {
+	ProposalID string      `json:"proposal_id"`
+	Extra      []byte      `json:"extra"`
+	Receipt    *pb.Receipt `json:"-"`
+}
+
+type RegisterAppchainRequest struct {
+	ChainID        string
+	ChainName      string
+	ChainIcon      []byte
+	ChainType      string
+	TrustRoot      []byte
+	Broker         string
+	Desc           string
+	MasterRuleAddr string
+	MasterRuleURL  string
+	AdminAddrs     []string
+	Reason         string
+}
+
+type UpdateAppchainRequest struct {
+	ChainID    string
+	ChainName  string
+	Desc       string
+	TrustRoot  []byte
+	AdminAddrs []string
+	Reason     string
+}
+
+type RegisterServiceRequest struct {
+	ChainID   string
+	ServiceID string
+	Name      string
+	Type      string
+	Intro     string
+	Ordered   bool
+	// Permits lists the full IDs of the services allowed to call this one
+	Permits []string
+	Details string
+	Reason  string
+}
+
+type UpdateServiceRequest struct {
+	// ChainServiceID is the service ID prefixed by its appchain ID, as "chainID:serviceID"
+	ChainServiceID string
+	Name           string
+	Intro          string
+	Permits        []string
+	Details        string
+	Reason         string
+}
+
+type RegisterNodeRequest struct {
+	NodeAccount string
+	NodeType    string
+	Pid         string
+	VPNodeID    uint64
+	NodeName    string
+	// Permits lists the appchains the node may access
+	Permits []string
+	Reason  string
+}
+
+type RegisterRuleRequest struct {
+	ChainID  string
+	RuleAddr string
+	RuleURL  string
+}
+
+type governanceClient struct {
+	cli *ChainClient
+}
+
+var _ Governance = (*governanceClient)(nil)
+
+// Governance returns the typed client of the BitXHub governance contracts.
+func (cli *ChainClient) Governance() Governance {
+	return &governanceClient{cli: cli}
+}
+
+func (g *governanceClient) RegisterAppchain(ctx context.Context, req *RegisterAppchainRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.AppchainMgrContractAddr, "RegisterAppchain", opts,
+		String(req.ChainID),
+		String(req.ChainName),
+		Bytes(req.ChainIcon),
+		String(req.ChainType),
+		Bytes(req.TrustRoot),
+		String(req.Broker),
+		String(req.Desc),
+		String(req.MasterRuleAddr),
+		String(req.MasterRuleURL),
+		String(strings.Join(req.AdminAddrs, ",")),
+		String(req.Reason),
+	)
+}
+
+func (g *governanceClient) UpdateAppchain(ctx context.Context, req *UpdateAppchainRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.AppchainMgrContractAddr, "UpdateAppchain", opts,
+		String(req.ChainID),
+		String(req.ChainName),
+		String(req.Desc),
+		Bytes(req.TrustRoot),
+		String(strings.Join(req.AdminAddrs, ",")),
+		String(req.Reason),
+	)
+}
+
+func (g *governanceClient) FreezeAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.AppchainMgrContractAddr, "FreezeAppchain", opts, String(chainID), String(reason))
+}
+
+func (g *governanceClient) ActivateAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.AppchainMgrContractAddr, "ActivateAppchain", opts, String(chainID), String(reason))
+}
+
+func (g *governanceClient) LogoutAppchain(ctx context.Context, chainID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.AppchainMgrContractAddr, "LogoutAppchain", opts, String(chainID), String(reason))
+}
+
+func (g *governanceClient) RegisterService(ctx context.Context, req *RegisterServiceRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	ordered := uint64(0)
+	if req.Ordered {
+		ordered = 1
+	}
+	return g.invoke(ctx, constant.ServiceMgrContractAddr, "RegisterService", opts,
+		String(req.ChainID),
+		String(req.ServiceID),
+		String(req.Name),
+		String(req.Type),
+		String(req.Intro),
+		Uint64(ordered),
+		String(strings.Join(req.Permits, ",")),
+		String(req.Details),
+		String(req.Reason),
+	)
+}
+
+func (g *governanceClient) UpdateService(ctx context.Context, req *UpdateServiceRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.ServiceMgrContractAddr, "UpdateService", opts,
+		String(req.ChainServiceID),
+		String(req.Name),
+		String(req.Intro),
+		String(strings.Join(req.Permits, ",")),
+		String(req.Details),
+		String(req.Reason),
+	)
+}
+
+func (g *governanceClient) FreezeService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.ServiceMgrContractAddr, "FreezeService", opts, String(chainServiceID), String(reason))
+}
+
+func (g *governanceClient) ActivateService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.ServiceMgrContractAddr, "ActivateService", opts, String(chainServiceID), String(reason))
+}
+
+func (g *governanceClient) LogoutService(ctx context.Context, chainServiceID, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.ServiceMgrContractAddr, "LogoutService", opts, String(chainServiceID), String(reason))
+}
+
+func (g *governanceClient) RegisterNode(ctx context.Context, req *RegisterNodeRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.NodeManagerContractAddr, "RegisterNode", opts,
+		String(req.NodeAccount),
+		String(req.NodeType),
+		String(req.Pid),
+		Uint64(req.VPNodeID),
+		String(req.NodeName),
+		String(strings.Join(req.Permits, ",")),
+		String(req.Reason),
+	)
+}
+
+func (g *governanceClient) LogoutNode(ctx context.Context, nodeAccount, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.NodeManagerContractAddr, "LogoutNode", opts, String(nodeAccount), String(reason))
+}
+
+func (g *governanceClient) RegisterRule(ctx context.Context, req *RegisterRuleRequest, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.RuleManagerContractAddr, "RegisterRule", opts,
+		String(req.ChainID),
+		String(req.RuleAddr),
+		String(req.RuleURL),
+	)
+}
+
+func (g *governanceClient) UpdateMasterRule(ctx context.Context, chainID, ruleAddr, reason string, opts *TransactOpts) (*GovernanceResult, error) {
+	return g.invoke(ctx, constant.RuleManagerContractAddr, "UpdateMasterRule", opts, String(chainID), String(ruleAddr), String(reason))
+}
+
+func (g *governanceClient) invoke(ctx context.Context, contract constant.BoltContractAddress, method string, opts *TransactOpts, args ...*pb.Arg) (*GovernanceResult, error) {
+	receipt, err := g.cli.invokeBVM(ctx, contract, method, opts, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGovernanceResult(method, receipt)
+}
+
+func parseGovernanceResult(method string, receipt *pb.Receipt) (*GovernanceResult, error) {
+	result := &GovernanceResult{Receipt: receipt}
+	if len(receipt.Ret) != 0 {
+		if err := json.Unmarshal(receipt.Ret, result); err != nil {
+			return nil, fmt.Errorf("unmarshal %s result: %w", method, err)
+		}
+	}
+	return result, nil
+}
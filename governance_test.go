@@ -0,0 +1,73 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeInvoke returns the contract method and args invoked by tx.
+func decodeInvoke(t *testing.T, tx *pb.BxhTransaction) (string, []*pb.Arg) {
+	td := &pb.TransactionData{}
+	require.Nil(t, td.Unmarshal(tx.Payload))
+	pl := &pb.InvokePayload{}
+	require.Nil(t, pl.Unmarshal(td.Payload))
+	return pl.Method, pl.Args
+}
+
+func TestGovernance_RegisterAppchain(t *testing.T) {
+	invoked := make(chan *pb.BxhTransaction, 1)
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.HandleInvoke(constant.AppchainMgrContractAddr.Address(), func(tx *pb.BxhTransaction, _ string, _ []*pb.Arg) ([]byte, error) {
+		invoked <- tx
+		return []byte(`{"proposal_id":"0x123-1","extra":"YXBwY2hhaW4x"}`), nil
+	})
+
+	ret, err := cli.Governance().RegisterAppchain(context.Background(), &RegisterAppchainRequest{
+		ChainID:        "appchain1",
+		ChainName:      "chain one",
+		ChainType:      "ETH",
+		TrustRoot:      []byte("validators"),
+		Broker:         "0xbroker",
+		Desc:           "desc",
+		MasterRuleAddr: HappyRuleAddr,
+		MasterRuleURL:  "url",
+		AdminAddrs:     []string{"0xa", "0xb"},
+		Reason:         "reason",
+	}, nil)
+	require.Nil(t, err)
+	require.Equal(t, "0x123-1", ret.ProposalID)
+	require.Equal(t, []byte("appchain1"), ret.Extra)
+	require.True(t, ret.Receipt.IsSuccess())
+
+	tx := <-invoked
+	require.Equal(t, constant.AppchainMgrContractAddr.Address().String(), tx.To.String())
+	method, args := decodeInvoke(t, tx)
+	require.Equal(t, "RegisterAppchain", method)
+	require.Len(t, args, 11)
+	for i, expected := range []string{"appchain1", "chain one", "", "ETH", "validators", "0xbroker", "desc", HappyRuleAddr, "url", "0xa,0xb", "reason"} {
+		require.Equal(t, expected, string(args[i].Value), "arg %d", i)
+	}
+}
+
+func TestGovernance_Failed(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.HandleInvoke(constant.AppchainMgrContractAddr.Address(), func(_ *pb.BxhTransaction, method string, _ []*pb.Arg) ([]byte, error) {
+		if method == "LogoutAppchain" {
+			return nil, errors.New("appchain is not available")
+		}
+		return []byte(`{"proposal_id":"0x123-2"}`), nil
+	})
+
+	ret, err := cli.Governance().FreezeAppchain(context.Background(), "appchain1", "reason", nil)
+	require.Nil(t, err)
+	require.Equal(t, "0x123-2", ret.ProposalID)
+
+	_, err = cli.Governance().LogoutAppchain(context.Background(), "appchain1", "reason", nil)
+	require.EqualError(t, err, fmt.Sprintf("%s failed: %s", "LogoutAppchain", "appchain is not available"))
+}
@@ -0,0 +1,255 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const defaultHeaderLimit = 10000
+
+var (
+	// ErrHeaderMismatch is returned when a header conflicts with the tracked chain,
+	// either because it doesn't link to its parent or because of a reorg
+	ErrHeaderMismatch = errors.New("block header mismatch")
+
+	// ErrHeaderUnknown is returned when a height is not tracked (anymore)
+	ErrHeaderUnknown = errors.New("block header is not tracked")
+)
+
+// HeaderStore persists the verified header chain of a HeaderTracker.
+type HeaderStore interface {
+	// StoreHeader is called with every header before it's appended to the chain.
+	StoreHeader(header *pb.BlockHeader) error
+
+	// LoadHeaders returns the persisted headers in height order.
+	LoadHeaders() ([]*pb.BlockHeader, error)
+}
+
+type HeaderTrackerOption func(*HeaderTracker)
+
+// WithHeaderStore persists the tracked headers and restores them on creation.
+func WithHeaderStore(store HeaderStore) HeaderTrackerOption {
+	return func(tracker *HeaderTracker) {
+		tracker.store = store
+	}
+}
+
+// WithFinalityDepth sets how many blocks must follow a block before it's final.
+// BitXHub's consensus finalizes a block once it's committed, so it's 0 by default.
+func WithFinalityDepth(depth uint64) HeaderTrackerOption {
+	return func(tracker *HeaderTracker) {
+		tracker.finality = depth
+	}
+}
+
+// WithHeaderLimit sets the max number of latest headers kept in memory.
+func WithHeaderLimit(limit uint64) HeaderTrackerOption {
+	return func(tracker *HeaderTracker) {
+		tracker.limit = limit
+	}
+}
+
+type trackedHeader struct {
+	header *pb.BlockHeader
+	hash   *types.Hash
+}
+
+// HeaderTracker keeps a chain of block headers linked by parent hash, starting
+// from the first header it's given, which is trusted as the anchor.
+type HeaderTracker struct {
+	cli      *ChainClient
+	store    HeaderStore
+	finality uint64
+	limit    uint64
+
+	mu      sync.RWMutex
+	headers map[uint64]*trackedHeader
+	oldest  uint64
+	latest  uint64
+}
+
+func NewHeaderTracker(cli *ChainClient, opts ...HeaderTrackerOption) (*HeaderTracker, error) {
+	tracker := &HeaderTracker{
+		cli:     cli,
+		limit:   defaultHeaderLimit,
+		headers: make(map[uint64]*trackedHeader),
+	}
+	for _, opt := range opts {
+		opt(tracker)
+	}
+	if tracker.limit == 0 {
+		tracker.limit = defaultHeaderLimit
+	}
+
+	if tracker.store != nil {
+		headers, err := tracker.store.LoadHeaders()
+		if err != nil {
+			return nil, fmt.Errorf("load headers: %w", err)
+		}
+		for _, header := range headers {
+			if err := tracker.add(header, false); err != nil {
+				return nil, fmt.Errorf("restore header %d: %w", header.GetNumber(), err)
+			}
+		}
+	}
+
+	return tracker, nil
+}
+
+// Add verifies the header against the tracked chain and appends it. Headers at
+// tracked heights are ignored if they match and rejected with ErrHeaderMismatch
+// otherwise.
+func (tracker *HeaderTracker) Add(header *pb.BlockHeader) error {
+	return tracker.add(header, true)
+}
+
+func (tracker *HeaderTracker) add(header *pb.BlockHeader, persist bool) error {
+	if header == nil {
+		return fmt.Errorf("block header is nil")
+	}
+	hash := header.Hash()
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	if tracker.latest != 0 {
+		if header.Number <= tracker.latest {
+			tracked, ok := tracker.headers[header.Number]
+			if ok && tracked.hash.String() != hash.String() {
+				return fmt.Errorf("%w: got %s at height %d, tracked %s", ErrHeaderMismatch, hash, header.Number, tracked.hash)
+			}
+			return nil
+		}
+		if header.Number != tracker.latest+1 {
+			return fmt.Errorf("got block header %d, expect %d", header.Number, tracker.latest+1)
+		}
+		parent := tracker.headers[tracker.latest]
+		if header.ParentHash == nil || header.ParentHash.String() != parent.hash.String() {
+			return fmt.Errorf("%w: parent hash of block %d is %s, tracked %s", ErrHeaderMismatch, header.Number, header.ParentHash, parent.hash)
+		}
+	}
+
+	if persist && tracker.store != nil {
+		if err := tracker.store.StoreHeader(header); err != nil {
+			return fmt.Errorf("store header %d: %w", header.Number, err)
+		}
+	}
+
+	tracker.headers[header.Number] = &trackedHeader{header: header, hash: hash}
+	tracker.latest = header.Number
+	if tracker.oldest == 0 {
+		tracker.oldest = header.Number
+	}
+	for tracker.latest-tracker.oldest+1 > tracker.limit {
+		delete(tracker.headers, tracker.oldest)
+		tracker.oldest++
+	}
+	return nil
+}
+
+// Latest returns the latest tracked header, or nil if nothing is tracked yet.
+func (tracker *HeaderTracker) Latest() *pb.BlockHeader {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+	if tracked, ok := tracker.headers[tracker.latest]; ok {
+		return tracked.header
+	}
+	return nil
+}
+
+// Header returns the tracked header at height.
+func (tracker *HeaderTracker) Header(height uint64) (*pb.BlockHeader, bool) {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+	tracked, ok := tracker.headers[height]
+	if !ok {
+		return nil, false
+	}
+	return tracked.header, true
+}
+
+// IsFinal reports whether block height with the given hash is final. It's false
+// while the block is not reached yet, and an error wrapping ErrHeaderMismatch is
+// returned if the tracked block at height has another hash.
+func (tracker *HeaderTracker) IsFinal(height uint64, hash string) (bool, error) {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+
+	if tracker.latest == 0 || height > tracker.latest {
+		return false, nil
+	}
+	tracked, ok := tracker.headers[height]
+	if !ok {
+		return false, fmt.Errorf("%w: %d", ErrHeaderUnknown, height)
+	}
+	if tracked.hash.String() != hash {
+		return false, fmt.Errorf("%w: block %d is %s, not %s", ErrHeaderMismatch, height, tracked.hash, hash)
+	}
+	return tracker.latest >= height+tracker.finality, nil
+}
+
+// Sync follows the block headers of BitXHub and adds them to the chain until ctx
+// is done or a header is rejected. It resumes after the latest tracked header,
+// or from height if nothing is tracked (0 starts from the next block).
+func (tracker *HeaderTracker) Sync(ctx context.Context, height uint64) error {
+	tracker.mu.RLock()
+	if tracker.latest != 0 {
+		height = tracker.latest + 1
+	}
+	tracker.mu.RUnlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for header := range tracker.cli.SubscribeBlockHeadersFrom(ctx, height) {
+		if err := tracker.Add(header); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// CrossCheck queries the block at height from every configured node and reports
+// the nodes whose header differs from the tracked one with ErrHeaderMismatch.
+// Nodes that can't be reached are skipped.
+func (tracker *HeaderTracker) CrossCheck(ctx context.Context, height uint64) error {
+	tracker.mu.RLock()
+	tracked, ok := tracker.headers[height]
+	tracker.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrHeaderUnknown, height)
+	}
+
+	var mismatched []string
+	pool := tracker.cli.pool
+	for _, node := range pool.health.nodes {
+		node := node
+		var block *pb.Block
+		err := tracker.cli.invokeOn(ctx, &CallInfo{Method: "GetBlock", Attempt: 1}, GetBlockTimeout, func(ctx context.Context) (*grpcClient, error) {
+			return pool.clientOf(ctx, node)
+		}, func(ctx context.Context, client *grpcClient) (err error) {
+			block, err = client.broker.GetBlock(ctx, &pb.GetBlockRequest{
+				Type:  pb.GetBlockRequest_HEIGHT,
+				Value: strconv.FormatUint(height, 10),
+			})
+			return err
+		})
+		if err != nil {
+			tracker.cli.logger.Warningf("cross check block %d: node %s: %s", height, node.info.Addr, err)
+			continue
+		}
+		if block.BlockHeader == nil || block.BlockHeader.Hash().String() != tracked.hash.String() {
+			mismatched = append(mismatched, node.info.Addr)
+		}
+	}
+
+	if len(mismatched) != 0 {
+		return fmt.Errorf("%w: block %d differs on nodes %v", ErrHeaderMismatch, height, mismatched)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package rpcx
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHeaders builds a header chain from height from to to.
+func newTestHeaders(from, to uint64, txRoot string) []*pb.BlockHeader {
+	var (
+		headers []*pb.BlockHeader
+		parent  = types.NewHash([]byte("parent"))
+	)
+	for i := from; i <= to; i++ {
+		header := &pb.BlockHeader{
+			Number:     i,
+			ParentHash: parent,
+			TxRoot:     types.NewHash([]byte(txRoot)),
+		}
+		headers = append(headers, header)
+		parent = header.Hash()
+	}
+	return headers
+}
+
+type memHeaderStore struct {
+	mu      sync.Mutex
+	headers []*pb.BlockHeader
+}
+
+func (s *memHeaderStore) StoreHeader(header *pb.BlockHeader) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.headers = append(s.headers, header)
+	return nil
+}
+
+func (s *memHeaderStore) LoadHeaders() ([]*pb.BlockHeader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.headers, nil
+}
+
+func TestHeaderTracker_Add(t *testing.T) {
+	store := &memHeaderStore{}
+	tracker, err := NewHeaderTracker(nil, WithHeaderStore(store), WithFinalityDepth(2), WithHeaderLimit(4))
+	require.Nil(t, err)
+	require.Nil(t, tracker.Latest())
+
+	headers := newTestHeaders(10, 15, "root")
+	for _, header := range headers[:4] {
+		require.Nil(t, tracker.Add(header))
+	}
+	require.Equal(t, uint64(13), tracker.Latest().Number)
+
+	// duplicates are ignored, conflicts and gaps are rejected
+	require.Nil(t, tracker.Add(headers[2]))
+	forked := newTestHeaders(10, 15, "fork")
+	require.ErrorIs(t, tracker.Add(forked[2]), ErrHeaderMismatch)
+	require.ErrorIs(t, tracker.Add(forked[4]), ErrHeaderMismatch)
+	require.NotNil(t, tracker.Add(headers[5]))
+
+	final, err := tracker.IsFinal(11, headers[1].Hash().String())
+	require.Nil(t, err)
+	require.True(t, final)
+	final, err = tracker.IsFinal(12, headers[2].Hash().String())
+	require.Nil(t, err)
+	require.False(t, final)
+	final, err = tracker.IsFinal(20, "")
+	require.Nil(t, err)
+	require.False(t, final)
+	_, err = tracker.IsFinal(11, forked[1].Hash().String())
+	require.ErrorIs(t, err, ErrHeaderMismatch)
+
+	// the oldest header is dropped beyond the limit
+	require.Nil(t, tracker.Add(headers[4]))
+	_, ok := tracker.Header(10)
+	require.False(t, ok)
+	_, err = tracker.IsFinal(10, headers[0].Hash().String())
+	require.ErrorIs(t, err, ErrHeaderUnknown)
+
+	// the chain is restored from the store
+	restored, err := NewHeaderTracker(nil, WithHeaderStore(store))
+	require.Nil(t, err)
+	require.Equal(t, uint64(14), restored.Latest().Number)
+	header, ok := restored.Header(10)
+	require.True(t, ok)
+	require.Equal(t, headers[0].Hash().String(), header.Hash().String())
+}
+
+// headerAt returns the block header of broker at height.
+func headerAt(t *testing.T, broker *rpcxtest.Broker, height uint64) *pb.BlockHeader {
+	block, err := broker.GetBlock(context.Background(), &pb.GetBlockRequest{
+		Type:  pb.GetBlockRequest_HEIGHT,
+		Value: strconv.FormatUint(height, 10),
+	})
+	require.Nil(t, err)
+	return block.BlockHeader
+}
+
+func TestHeaderTracker_Sync(t *testing.T) {
+	honest, cli, _ := newBrokerClient(t, nil)
+	forked, forker, _ := newBrokerClient(t, nil)
+	mine(t, cli, 2)
+	mine(t, forker, 2)
+
+	tracker, err := NewHeaderTracker(cli)
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- tracker.Sync(ctx, 1)
+	}()
+
+	mine(t, cli, 2)
+	require.Eventually(t, func() bool {
+		final, err := tracker.IsFinal(5, headerAt(t, honest, 5).Hash().String())
+		return err == nil && final
+	}, 5*time.Second, 50*time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// two nodes of the same chain agree on its headers
+	_, consistent, _ := newBrokerClient(t, []*rpcxtest.Broker{honest, honest})
+	tracker, err = NewHeaderTracker(consistent)
+	require.Nil(t, err)
+	require.Nil(t, tracker.Add(headerAt(t, honest, 2)))
+	require.Nil(t, tracker.CrossCheck(context.Background(), 2))
+
+	_, inconsistent, _ := newBrokerClient(t, []*rpcxtest.Broker{honest, forked})
+	tracker, err = NewHeaderTracker(inconsistent)
+	require.Nil(t, err)
+	require.Nil(t, tracker.Add(headerAt(t, honest, 2)))
+	require.ErrorIs(t, tracker.CrossCheck(context.Background(), 2), ErrHeaderMismatch)
+	require.ErrorIs(t, tracker.CrossCheck(context.Background(), 3), ErrHeaderUnknown)
+}
@@ -0,0 +1,282 @@
+package rpcx
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultMaxFailures     = 3
+	defaultEjectBackoff    = 1 * time.Second
+	defaultMaxEjectBackoff = 1 * time.Minute
+
+	// weight of the newest sample in the latency moving average
+	latencyWeight = 0.3
+)
+
+// NodeStatus is a snapshot of the health the pool has observed for a node.
+type NodeStatus struct {
+	Info                *NodeInfo
+	ConsecutiveFailures uint64
+	LastSuccess         time.Time
+	Latency             time.Duration
+	Healthy             bool
+}
+
+// NodeSelector decides which BitXHub node the pool dials next.
+type NodeSelector interface {
+	// Select returns the index of the chosen node in candidates.
+	// Candidates are the healthy nodes, in the order they were configured.
+	Select(candidates []NodeStatus) int
+}
+
+type roundRobinSelector struct {
+	next uint64
+}
+
+// RoundRobinSelector cycles through the healthy nodes.
+func RoundRobinSelector() NodeSelector {
+	return &roundRobinSelector{}
+}
+
+func (s *roundRobinSelector) Select(candidates []NodeStatus) int {
+	return int((atomic.AddUint64(&s.next, 1) - 1) % uint64(len(candidates)))
+}
+
+type randomSelector struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// RandomSelector picks a random healthy node, which is the default.
+func RandomSelector() NodeSelector {
+	return &randomSelector{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+func (s *randomSelector) Select(candidates []NodeStatus) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Intn(len(candidates))
+}
+
+type leastLatencySelector struct{}
+
+// LeastLatencySelector picks the healthy node with the lowest observed latency.
+// Nodes without any latency sample are tried first.
+func LeastLatencySelector() NodeSelector {
+	return leastLatencySelector{}
+}
+
+func (leastLatencySelector) Select(candidates []NodeStatus) int {
+	best := 0
+	for i, candidate := range candidates {
+		if candidate.Latency < candidates[best].Latency {
+			best = i
+		}
+	}
+	return best
+}
+
+type primarySelector struct{}
+
+// PrimarySelector sticks to the first configured node while it is healthy and
+// falls back to the next healthy one in configuration order.
+func PrimarySelector() NodeSelector {
+	return primarySelector{}
+}
+
+func (primarySelector) Select(candidates []NodeStatus) int {
+	return 0
+}
+
+type nodeHealth struct {
+	info *NodeInfo
+
+	mu           sync.Mutex
+	failures     uint64
+	lastSuccess  time.Time
+	latency      time.Duration
+	backoff      time.Duration
+	ejectedUntil time.Time
+}
+
+func (node *nodeHealth) status(now time.Time) NodeStatus {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	return NodeStatus{
+		Info:                node.info,
+		ConsecutiveFailures: node.failures,
+		LastSuccess:         node.lastSuccess,
+		Latency:             node.latency,
+		Healthy:             !now.Before(node.ejectedUntil),
+	}
+}
+
+// healthTracker keeps per-node health and ejects nodes which keep failing.
+// An ejected node is re-probed after its backoff, which doubles every time
+// the node is ejected again and is reset by a success.
+type healthTracker struct {
+	nodes       []*nodeHealth
+	selector    NodeSelector
+	maxFailures uint64
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+func newHealthTracker(config *config) *healthTracker {
+	nodes := make([]*nodeHealth, 0, len(config.nodesInfo))
+	for _, info := range config.nodesInfo {
+		nodes = append(nodes, &nodeHealth{info: info})
+	}
+	return &healthTracker{
+		nodes:       nodes,
+		selector:    config.nodeSelector,
+		maxFailures: config.maxFailures,
+		minBackoff:  config.ejectBackoff,
+		maxBackoff:  config.maxEjectBackoff,
+	}
+}
+
+// pick selects a healthy node. If every node is ejected, the one which
+// becomes eligible for re-probing first is returned even before its backoff
+// has passed, so that the calls keep probing the nodes instead of failing
+// without trying any of them.
+func (tracker *healthTracker) pick() *nodeHealth {
+	now := time.Now()
+	var (
+		candidates []NodeStatus
+		healthy    []*nodeHealth
+		soonest    *nodeHealth
+		soonestAt  time.Time
+	)
+	for _, node := range tracker.nodes {
+		st := node.status(now)
+		if st.Healthy {
+			candidates = append(candidates, st)
+			healthy = append(healthy, node)
+			continue
+		}
+		node.mu.Lock()
+		until := node.ejectedUntil
+		node.mu.Unlock()
+		if soonest == nil || until.Before(soonestAt) {
+			soonest, soonestAt = node, until
+		}
+	}
+	if len(healthy) == 0 {
+		return soonest
+	}
+
+	index := tracker.selector.Select(candidates)
+	if index < 0 || index >= len(healthy) {
+		index = 0
+	}
+	return healthy[index]
+}
+
+func (tracker *healthTracker) reportSuccess(node *nodeHealth, latency time.Duration) {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	node.failures = 0
+	node.backoff = 0
+	node.ejectedUntil = time.Time{}
+	node.lastSuccess = time.Now()
+	if node.latency == 0 {
+		node.latency = latency
+	} else {
+		node.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(node.latency))
+	}
+}
+
+func (tracker *healthTracker) reportFailure(node *nodeHealth) {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	node.failures++
+	if node.failures < tracker.maxFailures {
+		return
+	}
+	if node.backoff == 0 {
+		node.backoff = tracker.minBackoff
+	} else {
+		node.backoff *= 2
+	}
+	if node.backoff > tracker.maxBackoff {
+		node.backoff = tracker.maxBackoff
+	}
+	node.ejectedUntil = time.Now().Add(node.backoff)
+}
+
+func (tracker *healthTracker) statuses() []NodeStatus {
+	now := time.Now()
+	ret := make([]NodeStatus, 0, len(tracker.nodes))
+	for _, node := range tracker.nodes {
+		ret = append(ret, node.status(now))
+	}
+	return ret
+}
+
+// isNodeFailure tells whether a grpc error of a call with ctx indicates that
+// the node itself is unreachable or unresponsive rather than rejecting the
+// request. A deadline exceeded as the caller gave up on its own deadline tells
+// nothing about the node, unlike one of the timeouts of the client.
+func isNodeFailure(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		return true
+	case codes.DeadlineExceeded:
+		return !callerGaveUp(ctx)
+	default:
+		return false
+	}
+}
+
+func (tracker *healthTracker) unaryInterceptor(node *nodeHealth) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			tracker.reportSuccess(node, time.Since(start))
+		} else if isNodeFailure(ctx, err) {
+			tracker.reportFailure(node)
+		}
+		return err
+	}
+}
+
+func (tracker *healthTracker) streamInterceptor(node *nodeHealth) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			if isNodeFailure(ctx, err) {
+				tracker.reportFailure(node)
+			}
+			return nil, err
+		}
+		tracker.reportSuccess(node, time.Since(start))
+		return &healthStream{ClientStream: stream, tracker: tracker, node: node}, nil
+	}
+}
+
+// healthStream reports a node failure when a long-lived stream breaks, so that
+// resubscribing doesn't go back to a node that just went away.
+type healthStream struct {
+	grpc.ClientStream
+	tracker *healthTracker
+	node    *nodeHealth
+}
+
+func (s *healthStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil && isNodeFailure(s.Context(), err) {
+		s.tracker.reportFailure(s.node)
+	}
+	return err
+}
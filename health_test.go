@@ -0,0 +1,142 @@
+package rpcx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestTracker(selector NodeSelector, addrs ...string) *healthTracker {
+	config := &config{
+		nodeSelector:    selector,
+		maxFailures:     2,
+		ejectBackoff:    100 * time.Millisecond,
+		maxEjectBackoff: 300 * time.Millisecond,
+	}
+	for _, addr := range addrs {
+		config.nodesInfo = append(config.nodesInfo, &NodeInfo{Addr: addr})
+	}
+	return newHealthTracker(config)
+}
+
+func TestHealthTracker_Eject(t *testing.T) {
+	tracker := newTestTracker(PrimarySelector(), "node1", "node2")
+	node1 := tracker.nodes[0]
+
+	require.Equal(t, node1, tracker.pick())
+
+	tracker.reportFailure(node1)
+	require.Equal(t, node1, tracker.pick())
+
+	// the second consecutive failure ejects node1
+	tracker.reportFailure(node1)
+	require.Equal(t, "node2", tracker.pick().info.Addr)
+	require.False(t, tracker.statuses()[0].Healthy)
+
+	// node1 is re-probed once the backoff passes
+	time.Sleep(150 * time.Millisecond)
+	require.Equal(t, node1, tracker.pick())
+
+	// failing the probe doubles the backoff
+	tracker.reportFailure(node1)
+	require.Equal(t, 200*time.Millisecond, node1.backoff)
+	tracker.reportFailure(node1)
+	tracker.reportFailure(node1)
+	require.Equal(t, 300*time.Millisecond, node1.backoff)
+
+	tracker.reportSuccess(node1, 10*time.Millisecond)
+	st := tracker.statuses()[0]
+	require.True(t, st.Healthy)
+	require.Equal(t, uint64(0), st.ConsecutiveFailures)
+	require.Equal(t, 10*time.Millisecond, st.Latency)
+	require.Equal(t, node1, tracker.pick())
+}
+
+func TestHealthTracker_AllEjected(t *testing.T) {
+	tracker := newTestTracker(RandomSelector(), "node1", "node2")
+	for i := 0; i < 2; i++ {
+		tracker.reportFailure(tracker.nodes[1])
+	}
+	time.Sleep(10 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		tracker.reportFailure(tracker.nodes[0])
+	}
+
+	// node2 was ejected first, so it is the first to be re-probed
+	require.Equal(t, "node2", tracker.pick().info.Addr)
+}
+
+func TestNodeSelector(t *testing.T) {
+	candidates := []NodeStatus{
+		{Info: &NodeInfo{Addr: "node1"}, Latency: 30 * time.Millisecond},
+		{Info: &NodeInfo{Addr: "node2"}, Latency: 10 * time.Millisecond},
+		{Info: &NodeInfo{Addr: "node3"}, Latency: 20 * time.Millisecond},
+	}
+
+	rr := RoundRobinSelector()
+	for i := 0; i < 6; i++ {
+		require.Equal(t, i%3, rr.Select(candidates))
+	}
+
+	require.Equal(t, 1, LeastLatencySelector().Select(candidates))
+	require.Equal(t, 0, PrimarySelector().Select(candidates))
+
+	random := RandomSelector()
+	for i := 0; i < 10; i++ {
+		index := random.Select(candidates)
+		require.True(t, index >= 0 && index < len(candidates))
+	}
+}
+
+func TestIsNodeFailure(t *testing.T) {
+	ctx := context.Background()
+	require.True(t, isNodeFailure(ctx, status.Error(codes.Unavailable, "connection refused")))
+	require.True(t, isNodeFailure(ctx, status.Error(codes.DeadlineExceeded, "timeout")))
+	require.False(t, isNodeFailure(ctx, status.Error(codes.InvalidArgument, "bad tx")))
+	require.False(t, isNodeFailure(ctx, nil))
+
+	// the deadline of the caller is no failure of the node
+	expired, cancel := context.WithTimeout(ctx, 0)
+	defer cancel()
+	require.False(t, isNodeFailure(expired, status.Error(codes.DeadlineExceeded, "timeout")))
+
+	// unlike a timeout of the client, as long as the caller's deadline is ahead
+	timedOut, cancel := withClientTimeout(ctx, 0)
+	defer cancel()
+	require.True(t, isNodeFailure(timedOut, status.Error(codes.DeadlineExceeded, "timeout")))
+	bounded, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	timedOut, cancel = withClientTimeout(bounded, 0)
+	defer cancel()
+	require.True(t, isNodeFailure(timedOut, status.Error(codes.DeadlineExceeded, "timeout")))
+	timedOut, cancel = withClientTimeout(expired, time.Minute)
+	defer cancel()
+	require.False(t, isNodeFailure(timedOut, status.Error(codes.DeadlineExceeded, "timeout")))
+}
+
+func TestChainClient_EjectStalledNode(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithNodeEjection(1, time.Minute, time.Minute),
+	)
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Delay: 2 * GetChainIDTimeout, Times: 2})
+
+	// the node isn't blamed for the deadline of the caller
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := cli.GetChainIDCtx(ctx)
+	require.ErrorIs(t, err, ErrTimeout)
+	require.True(t, cli.pool.NodeStatuses()[0].Healthy)
+
+	// but it is for running out of the default timeout of the call
+	_, err = cli.GetChainID()
+	require.ErrorIs(t, err, ErrTimeout)
+	status := cli.pool.NodeStatuses()[0]
+	require.Equal(t, uint64(1), status.ConsecutiveFailures)
+	require.False(t, status.Healthy)
+}
@@ -0,0 +1,184 @@
+package rpcx
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// FullServiceID formats the ID of a service as known to BitXHub interchains,
+// as "bxhID:chainID:serviceAddr".
+func FullServiceID(bxhID, chainID, serviceAddr string) string {
+	return fmt.Sprintf("%s:%s:%s", bxhID, chainID, serviceAddr)
+}
+
+// CheckFullServiceID checks that id has the "bxhID:chainID:serviceAddr" format.
+func CheckFullServiceID(id string) error {
+	bxhID, chainID, serviceAddr, err := pb.ParseFullServiceID(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrReconstruct, err.Error())
+	}
+	if bxhID == "" || chainID == "" || serviceAddr == "" || strings.TrimSpace(id) != id {
+		return fmt.Errorf("%w: invalid full service ID: %s", ErrReconstruct, id)
+	}
+	return nil
+}
+
+// EncryptFunc encrypts the content of an IBTP payload for its destination.
+type EncryptFunc func(content []byte) ([]byte, error)
+
+// IBTPBuilder builds an IBTP step by step. The first invalid step is reported
+// by Build, so steps can be chained without checking each of them:
+//
+//	ibtp, err := NewIBTPBuilder(from, to, index).
+//		Call("interchainCharge", []byte("Alice"), []byte("Bob"), []byte("1")).
+//		Proof(proof).
+//		Build()
+type IBTPBuilder struct {
+	ibtp    *pb.IBTP
+	content []byte
+	encrypt EncryptFunc
+	proof   []byte
+	err     error
+}
+
+// NewIBTPBuilder starts an INTERCHAIN IBTP with the given index from the service
+// from to the service to, both given by their full service IDs.
+func NewIBTPBuilder(from, to string, index uint64) *IBTPBuilder {
+	return &IBTPBuilder{
+		ibtp: &pb.IBTP{
+			From:  from,
+			To:    to,
+			Index: index,
+			Type:  pb.IBTP_INTERCHAIN,
+		},
+	}
+}
+
+func (b *IBTPBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Type sets the type of the IBTP, which is INTERCHAIN by default.
+func (b *IBTPBuilder) Type(typ pb.IBTP_Type) *IBTPBuilder {
+	switch typ {
+	case pb.IBTP_INTERCHAIN, pb.IBTP_RECEIPT_SUCCESS, pb.IBTP_RECEIPT_FAILURE, pb.IBTP_RECEIPT_ROLLBACK:
+		b.ibtp.Type = typ
+	default:
+		b.setErr(fmt.Errorf("%w: unsupported ibtp type %s", ErrReconstruct, typ))
+	}
+	return b
+}
+
+// Call sets the payload of an INTERCHAIN IBTP to the invocation of fn of the
+// destination service with args.
+func (b *IBTPBuilder) Call(fn string, args ...[]byte) *IBTPBuilder {
+	content := &pb.Content{Func: fn, Args: args}
+	data, err := content.Marshal()
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	b.content = data
+	return b
+}
+
+// Result sets the payload of a receipt IBTP to the result of the invocation.
+func (b *IBTPBuilder) Result(result *pb.Result) *IBTPBuilder {
+	data, err := result.Marshal()
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	b.content = data
+	return b
+}
+
+// Encrypt encrypts the payload content with fn. The payload then carries the
+// hash of the plain content for the destination to check it after decryption.
+func (b *IBTPBuilder) Encrypt(fn EncryptFunc) *IBTPBuilder {
+	b.encrypt = fn
+	return b
+}
+
+// TimeoutHeight sets the height of BitXHub after which the IBTP times out.
+func (b *IBTPBuilder) TimeoutHeight(height int64) *IBTPBuilder {
+	b.ibtp.TimeoutHeight = height
+	return b
+}
+
+// Proof sets the proof of the IBTP. The IBTP carries the hash of the proof,
+// while the proof itself goes into the extra field of the transaction built by
+// BuildTx.
+func (b *IBTPBuilder) Proof(proof []byte) *IBTPBuilder {
+	b.proof = proof
+	return b
+}
+
+// Extra sets the extra field of the IBTP.
+func (b *IBTPBuilder) Extra(extra []byte) *IBTPBuilder {
+	b.ibtp.Extra = extra
+	return b
+}
+
+// Build checks the IBTP and returns it.
+func (b *IBTPBuilder) Build() (*pb.IBTP, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := CheckFullServiceID(b.ibtp.From); err != nil {
+		return nil, err
+	}
+	if err := CheckFullServiceID(b.ibtp.To); err != nil {
+		return nil, err
+	}
+	if b.ibtp.Index == 0 {
+		return nil, fmt.Errorf("%w: ibtp index starts from 1", ErrReconstruct)
+	}
+	if b.ibtp.Type == pb.IBTP_INTERCHAIN && b.content == nil {
+		return nil, fmt.Errorf("%w: interchain ibtp without call", ErrReconstruct)
+	}
+
+	payload := &pb.Payload{Content: b.content}
+	if b.encrypt != nil {
+		hash := sha256.Sum256(b.content)
+		content, err := b.encrypt(b.content)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt ibtp payload: %w", err)
+		}
+		payload.Encrypted = true
+		payload.Content = content
+		payload.Hash = hash[:]
+	}
+	data, err := payload.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	ibtp := *b.ibtp
+	ibtp.Payload = data
+	if b.proof != nil {
+		hash := sha256.Sum256(b.proof)
+		ibtp.Proof = hash[:]
+	}
+	return &ibtp, nil
+}
+
+// BuildTx builds the IBTP into an interchain transaction of cli, with the proof
+// set as the transaction extra.
+func (b *IBTPBuilder) BuildTx(cli *ChainClient) (*pb.BxhTransaction, error) {
+	ibtp, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := cli.GenerateIBTPTx(ibtp)
+	if err != nil {
+		return nil, err
+	}
+	tx.Extra = b.proof
+	return tx, nil
+}
@@ -0,0 +1,93 @@
+package rpcx
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBTPBuilder(t *testing.T) {
+	proof := []byte("proof")
+	ibtp, err := NewIBTPBuilder(testFromService, testToService, 1).
+		Call("interchainCharge", []byte("Alice"), []byte("Bob"), []byte("1")).
+		TimeoutHeight(10).
+		Proof(proof).
+		Build()
+	require.Nil(t, err)
+	require.Equal(t, pb.IBTP_INTERCHAIN, ibtp.Type)
+	require.Equal(t, int64(10), ibtp.TimeoutHeight)
+	proofHash := sha256.Sum256(proof)
+	require.Equal(t, proofHash[:], ibtp.Proof)
+
+	payload := &pb.Payload{}
+	require.Nil(t, payload.Unmarshal(ibtp.Payload))
+	require.False(t, payload.Encrypted)
+	content := &pb.Content{}
+	require.Nil(t, content.Unmarshal(payload.Content))
+	require.Equal(t, "interchainCharge", content.Func)
+	require.Equal(t, [][]byte{[]byte("Alice"), []byte("Bob"), []byte("1")}, content.Args)
+
+	reverse := func(content []byte) ([]byte, error) {
+		encrypted := append([]byte(nil), content...)
+		for i, j := 0, len(encrypted)-1; i < j; i, j = i+1, j-1 {
+			encrypted[i], encrypted[j] = encrypted[j], encrypted[i]
+		}
+		return encrypted, nil
+	}
+	result := &pb.Result{Data: []*pb.ResultRes{{Data: [][]byte{[]byte("ok")}}}, MultiStatus: []bool{true}}
+	ibtp, err = NewIBTPBuilder(testFromService, testToService, 1).
+		Type(pb.IBTP_RECEIPT_SUCCESS).
+		Result(result).
+		Encrypt(reverse).
+		Build()
+	require.Nil(t, err)
+	require.Nil(t, payload.Unmarshal(ibtp.Payload))
+	require.True(t, payload.Encrypted)
+	plain, err := reverse(payload.Content)
+	require.Nil(t, err)
+	hash := sha256.Sum256(plain)
+	require.Equal(t, hash[:], payload.Hash)
+	decoded := &pb.Result{}
+	require.Nil(t, decoded.Unmarshal(plain))
+	require.Equal(t, result, decoded)
+}
+
+func TestIBTPBuilder_Invalid(t *testing.T) {
+	for name, builder := range map[string]*IBTPBuilder{
+		"from":    NewIBTPBuilder("appchain1:s1", testToService, 1).Call("f"),
+		"to":      NewIBTPBuilder(testFromService, "1356::s2", 1).Call("f"),
+		"index":   NewIBTPBuilder(testFromService, testToService, 0).Call("f"),
+		"call":    NewIBTPBuilder(testFromService, testToService, 1),
+		"type":    NewIBTPBuilder(testFromService, testToService, 1).Call("f").Type(pb.IBTP_RECEIPT_ROLLBACK_END),
+		"encrypt": NewIBTPBuilder(testFromService, testToService, 1).Call("f").Encrypt(func([]byte) ([]byte, error) { return nil, fmt.Errorf("no key") }),
+	} {
+		_, err := builder.Build()
+		require.NotNil(t, err, name)
+	}
+	_, err := NewIBTPBuilder("appchain1:s1", testToService, 1).Call("f").Build()
+	require.ErrorIs(t, err, ErrReconstruct)
+}
+
+func TestIBTPBuilder_BuildTx(t *testing.T) {
+	privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	signer, err := NewKeySigner(privKey)
+	require.Nil(t, err)
+	cli := &ChainClient{signer: signer}
+
+	tx, err := NewIBTPBuilder(testFromService, testToService, 2).
+		Type(pb.IBTP_RECEIPT_FAILURE).
+		Proof([]byte("proof")).
+		BuildTx(cli)
+	require.Nil(t, err)
+	require.Equal(t, constant.InterchainContractAddr.Address().String(), tx.To.String())
+	require.Equal(t, []byte("proof"), tx.Extra)
+	require.Equal(t, uint64(2), tx.IBTP.Index)
+	require.Equal(t, pb.IBTP_RECEIPT_FAILURE, tx.IBTP.Type)
+}
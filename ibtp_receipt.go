@@ -0,0 +1,121 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const defaultIBTPPollInterval = time.Second
+
+// IBTPStatusFinal reports whether an interchain transaction in status has
+// completed its round trip. BEGIN_FAILURE and BEGIN_ROLLBACK are on the way to
+// FAILURE and ROLLBACK, the latter being where an IBTP that timed out ends.
+func IBTPStatusFinal(status pb.TransactionStatus) bool {
+	switch status {
+	case pb.TransactionStatus_SUCCESS, pb.TransactionStatus_FAILURE, pb.TransactionStatus_ROLLBACK:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewReceiptIBTPBuilder starts the receipt of an interchain IBTP received by its
+// destination. typ is RECEIPT_SUCCESS or RECEIPT_FAILURE after the destination
+// has executed the IBTP, or RECEIPT_ROLLBACK for the source to roll it back.
+// The result of the execution, if any, is carried as the receipt payload.
+func NewReceiptIBTPBuilder(ibtp *pb.IBTP, typ pb.IBTP_Type, result *pb.Result) *IBTPBuilder {
+	b := NewIBTPBuilder(ibtp.From, ibtp.To, ibtp.Index)
+	if ibtp.Type != pb.IBTP_INTERCHAIN {
+		b.setErr(fmt.Errorf("%w: receipt of %s ibtp %s", ErrReconstruct, ibtp.Type, ibtp.ID()))
+	}
+	if typ == pb.IBTP_INTERCHAIN {
+		b.setErr(fmt.Errorf("%w: %s is not a receipt type", ErrReconstruct, typ))
+	}
+	b.Type(typ)
+	if result != nil {
+		b.Result(result)
+	}
+	return b
+}
+
+// SendIBTP builds the IBTP and sends it in an interchain transaction, failing
+// if the transaction is reverted by BitXHub.
+func (cli *ChainClient) SendIBTP(b *IBTPBuilder, opts *TransactOpts) (*pb.Receipt, error) {
+	return cli.SendIBTPCtx(context.Background(), b, opts)
+}
+
+func (cli *ChainClient) SendIBTPCtx(ctx context.Context, b *IBTPBuilder, opts *TransactOpts) (*pb.Receipt, error) {
+	tx, err := b.BuildTx(cli)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := cli.sendTransactionWithReceipt(ctx, tx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !receipt.IsSuccess() {
+		return nil, fmt.Errorf("handle ibtp %s failed: %s", tx.IBTP.ID(), string(receipt.Ret))
+	}
+	return receipt, nil
+}
+
+// SendIBTPReceipt sends the receipt of an interchain IBTP once the destination
+// has executed it, with RECEIPT_SUCCESS or RECEIPT_FAILURE by success.
+func (cli *ChainClient) SendIBTPReceipt(ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *TransactOpts) (*pb.Receipt, error) {
+	return cli.SendIBTPReceiptCtx(context.Background(), ibtp, success, result, proof, opts)
+}
+
+func (cli *ChainClient) SendIBTPReceiptCtx(ctx context.Context, ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *TransactOpts) (*pb.Receipt, error) {
+	typ := pb.IBTP_RECEIPT_FAILURE
+	if success {
+		typ = pb.IBTP_RECEIPT_SUCCESS
+	}
+	return cli.SendIBTPCtx(ctx, NewReceiptIBTPBuilder(ibtp, typ, result).Proof(proof), opts)
+}
+
+// GetIBTPStatus returns the status of the interchain transaction of an IBTP by
+// its ID, as "from-to-index".
+func (cli *ChainClient) GetIBTPStatus(id string) (pb.TransactionStatus, error) {
+	return cli.GetIBTPStatusCtx(context.Background(), id)
+}
+
+func (cli *ChainClient) GetIBTPStatusCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
+	ret, err := cli.viewBVM(ctx, constant.TransactionMgrContractAddr, "GetStatus", String(id))
+	if err != nil {
+		return 0, err
+	}
+	status, err := strconv.Atoi(string(ret))
+	if err != nil {
+		return 0, fmt.Errorf("parse status of ibtp %s: %w", id, err)
+	}
+	if _, ok := pb.TransactionStatus_name[int32(status)]; !ok {
+		return 0, fmt.Errorf("unknown status %d of ibtp %s", status, id)
+	}
+	return pb.TransactionStatus(status), nil
+}
+
+// WaitIBTP blocks until the interchain transaction of an IBTP is final, see
+// IBTPStatusFinal, and returns its status. Like WaitProposal, the status is
+// checked again on every new block. WaitIBTP waits without a deadline, use
+// WaitIBTPCtx to bound the wait.
+func (cli *ChainClient) WaitIBTP(id string) (pb.TransactionStatus, error) {
+	return cli.WaitIBTPCtx(context.Background(), id)
+}
+
+func (cli *ChainClient) WaitIBTPCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
+	var status pb.TransactionStatus
+	err := cli.waitOnBlocks(ctx, "ibtp "+id, defaultIBTPPollInterval, func(ctx context.Context) (bool, error) {
+		var err error
+		status, err = cli.GetIBTPStatusCtx(ctx, id)
+		return err == nil && IBTPStatusFinal(status), err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return status, nil
+}
@@ -0,0 +1,72 @@
+package rpcx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainClient_SendIBTPReceipt(t *testing.T) {
+	_, cli, _ := newBrokerClient(t, nil)
+	ctx := context.Background()
+
+	request := NewIBTPBuilder(testFromService, testToService, 1).Call("interchainCharge", []byte("Alice"))
+	_, err := cli.SendIBTPCtx(ctx, request, nil)
+	require.Nil(t, err)
+	ibtp, err := request.Build()
+	require.Nil(t, err)
+
+	status, err := cli.GetIBTPStatusCtx(ctx, ibtp.ID())
+	require.Nil(t, err)
+	require.Equal(t, pb.TransactionStatus_BEGIN, status)
+	_, err = cli.GetIBTPStatusCtx(ctx, "1356:appchain1:s1-1356:appchain2:s2-2")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	done := make(chan pb.TransactionStatus)
+	go func() {
+		status, err := cli.WaitIBTPCtx(ctx, ibtp.ID())
+		require.Nil(t, err)
+		done <- status
+	}()
+
+	result := &pb.Result{Data: []*pb.ResultRes{{Data: [][]byte{[]byte("ok")}}}}
+	sent, err := cli.SendIBTPReceiptCtx(ctx, ibtp, true, result, []byte("proof"), nil)
+	require.Nil(t, err)
+
+	select {
+	case status := <-done:
+		require.Equal(t, pb.TransactionStatus_SUCCESS, status)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ibtp is not final")
+	}
+
+	resp, err := cli.GetTransaction(sent.TxHash.String())
+	require.Nil(t, err)
+	receipt := resp.Tx.IBTP
+	require.Equal(t, ibtp.ID(), receipt.ID())
+	require.Equal(t, pb.IBTP_RECEIPT_SUCCESS, receipt.Type)
+	payload := &pb.Payload{}
+	require.Nil(t, payload.Unmarshal(receipt.Payload))
+	decoded := &pb.Result{}
+	require.Nil(t, decoded.Unmarshal(payload.Content))
+	require.Equal(t, result, decoded)
+
+	_, err = cli.SendIBTPReceiptCtx(ctx, receipt, false, nil, nil, nil)
+	require.ErrorIs(t, err, ErrReconstruct)
+}
+
+func TestIBTPStatusFinal(t *testing.T) {
+	for status, final := range map[pb.TransactionStatus]bool{
+		pb.TransactionStatus_BEGIN:          false,
+		pb.TransactionStatus_BEGIN_FAILURE:  false,
+		pb.TransactionStatus_BEGIN_ROLLBACK: false,
+		pb.TransactionStatus_SUCCESS:        true,
+		pb.TransactionStatus_FAILURE:        true,
+		pb.TransactionStatus_ROLLBACK:       true,
+	} {
+		require.Equal(t, final, IBTPStatusFinal(status), status.String())
+	}
+}
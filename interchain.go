@@ -0,0 +1,196 @@
+package rpcx
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// GetInterchainMeta returns the interchain counters of a service by its full
+// ID, as "bxhID:chainID:serviceID". Counters of a service which never took part
+// in an interchain are returned empty rather than nil.
+func (cli *ChainClient) GetInterchainMeta(serviceID string) (*Interchain, error) {
+	return cli.GetInterchainMetaCtx(context.Background(), serviceID)
+}
+
+func (cli *ChainClient) GetInterchainMetaCtx(ctx context.Context, serviceID string) (*Interchain, error) {
+	interchain, err := cli.GetInterchainCtx(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if interchain.ID == "" {
+		interchain.ID = serviceID
+	}
+	if interchain.InterchainCounter == nil {
+		interchain.InterchainCounter = make(map[string]uint64)
+	}
+	if interchain.ReceiptCounter == nil {
+		interchain.ReceiptCounter = make(map[string]uint64)
+	}
+	if interchain.SourceReceiptCounter == nil {
+		interchain.SourceReceiptCounter = make(map[string]uint64)
+	}
+	return interchain, nil
+}
+
+// NextIBTPIndex returns the index of the next IBTP the service sends to the
+// service to.
+func (interchain *Interchain) NextIBTPIndex(to string) uint64 {
+	return interchain.InterchainCounter[to] + 1
+}
+
+// NextReceiptIndex returns the index of the next receipt the service sends
+// back for the IBTPs from the service from.
+func (interchain *Interchain) NextReceiptIndex(from string) uint64 {
+	return interchain.ReceiptCounter[from] + 1
+}
+
+// PendingReceipts returns how many IBTPs the service sent to the service to
+// have not been answered by a receipt yet.
+func (interchain *Interchain) PendingReceipts(to string) uint64 {
+	sent, received := interchain.InterchainCounter[to], interchain.SourceReceiptCounter[to]
+	if received >= sent {
+		return 0
+	}
+	return sent - received
+}
+
+// IBTPGap is a range of IBTP indexes, from Begin to End inclusive, which BitXHub
+// has counted for a service pair but which were never seen.
+type IBTPGap struct {
+	From  string
+	To    string
+	Begin uint64
+	End   uint64
+}
+
+// IBTPIndexTracker follows the indexes of the interchain requests seen for each
+// service pair, e.g. through SubscribeInterchainTx, to tell the next expected
+// index of a pair and the indexes missed against the interchain counters.
+type IBTPIndexTracker struct {
+	mu    sync.Mutex
+	pairs map[string]*ibtpIndexes
+}
+
+// ibtpIndexes holds the indexes seen for a service pair. Every index up to
+// delivered has been seen, and seen holds the indexes seen above it.
+type ibtpIndexes struct {
+	delivered uint64
+	seen      map[uint64]struct{}
+}
+
+func NewIBTPIndexTracker() *IBTPIndexTracker {
+	return &IBTPIndexTracker{pairs: make(map[string]*ibtpIndexes)}
+}
+
+func (tracker *IBTPIndexTracker) indexes(from, to string) *ibtpIndexes {
+	pair := pb.GenServicePair(from, to)
+	indexes, ok := tracker.pairs[pair]
+	if !ok {
+		indexes = &ibtpIndexes{seen: make(map[uint64]struct{})}
+		tracker.pairs[pair] = indexes
+	}
+	return indexes
+}
+
+// Init takes the IBTPs counted by meta as seen, so that tracking can start from
+// a snapshot of the counters rather than from the first index.
+func (tracker *IBTPIndexTracker) Init(meta *Interchain) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	for to, counter := range meta.InterchainCounter {
+		indexes := tracker.indexes(meta.ID, to)
+		for index := range indexes.seen {
+			if index <= counter {
+				delete(indexes.seen, index)
+			}
+		}
+		if counter > indexes.delivered {
+			indexes.delivered = counter
+		}
+		indexes.advance()
+	}
+}
+
+// Observe records the index of an interchain request. It returns false if the
+// IBTP is not a request or its index has been seen already.
+func (tracker *IBTPIndexTracker) Observe(ibtp *pb.IBTP) bool {
+	if ibtp.Category() != pb.IBTP_REQUEST {
+		return false
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	indexes := tracker.indexes(ibtp.From, ibtp.To)
+	if _, ok := indexes.seen[ibtp.Index]; ok || ibtp.Index <= indexes.delivered {
+		return false
+	}
+	indexes.seen[ibtp.Index] = struct{}{}
+	indexes.advance()
+	return true
+}
+
+func (indexes *ibtpIndexes) advance() {
+	for {
+		if _, ok := indexes.seen[indexes.delivered+1]; !ok {
+			return
+		}
+		delete(indexes.seen, indexes.delivered+1)
+		indexes.delivered++
+	}
+}
+
+// NextIndex returns the index expected next from the service from to the
+// service to, following the highest index seen without a gap.
+func (tracker *IBTPIndexTracker) NextIndex(from, to string) uint64 {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	return tracker.indexes(from, to).delivered + 1
+}
+
+// Gaps returns the ranges of indexes counted by meta for the IBTPs it sent but
+// not seen by the tracker, ordered by destination and index.
+func (tracker *IBTPIndexTracker) Gaps(meta *Interchain) []IBTPGap {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	tos := make([]string, 0, len(meta.InterchainCounter))
+	for to := range meta.InterchainCounter {
+		tos = append(tos, to)
+	}
+	sort.Strings(tos)
+
+	var gaps []IBTPGap
+	for _, to := range tos {
+		counter := meta.InterchainCounter[to]
+		indexes := tracker.indexes(meta.ID, to)
+
+		seen := make([]uint64, 0, len(indexes.seen))
+		for index := range indexes.seen {
+			seen = append(seen, index)
+		}
+		sort.Slice(seen, func(i, j int) bool { return seen[i] < seen[j] })
+
+		begin := indexes.delivered + 1
+		for _, index := range append(seen, counter+1) {
+			if index > counter+1 {
+				index = counter + 1
+			}
+			if index > begin {
+				gaps = append(gaps, IBTPGap{From: meta.ID, To: to, Begin: begin, End: index - 1})
+			}
+			if index >= begin {
+				begin = index + 1
+			}
+			if begin > counter {
+				break
+			}
+		}
+	}
+	return gaps
+}
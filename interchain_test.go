@@ -0,0 +1,73 @@
+package rpcx
+
+import (
+	"testing"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFromService = "1356:appchain1:s1"
+	testToService   = "1356:appchain2:s2"
+)
+
+func TestChainClient_GetInterchainMeta(t *testing.T) {
+	interchainMgr := constant.InterchainContractAddr.Address().String()
+	broker, cli, _ := newBrokerClient(t, nil)
+	handleViews(broker, map[string]string{
+		interchainMgr + ",GetInterchain," + testFromService: `{"id":"1356:appchain1:s1","interchain_counter":{"1356:appchain2:s2":5},"source_receipt_counter":{"1356:appchain2:s2":3}}`,
+		interchainMgr + ",GetInterchain," + testToService:   `{}`,
+	})
+
+	meta, err := cli.GetInterchainMeta(testFromService)
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), meta.NextIBTPIndex(testToService))
+	require.Equal(t, uint64(1), meta.NextReceiptIndex(testToService))
+	require.Equal(t, uint64(2), meta.PendingReceipts(testToService))
+
+	meta, err = cli.GetInterchainMeta(testToService)
+	require.Nil(t, err)
+	require.Equal(t, testToService, meta.ID)
+	require.NotNil(t, meta.InterchainCounter)
+	require.NotNil(t, meta.ReceiptCounter)
+	require.NotNil(t, meta.SourceReceiptCounter)
+
+	_, err = cli.GetInterchainMeta("1356:appchain3:s3")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestIBTPIndexTracker(t *testing.T) {
+	ibtp := func(index uint64, typ pb.IBTP_Type) *pb.IBTP {
+		return &pb.IBTP{From: testFromService, To: testToService, Index: index, Type: typ}
+	}
+	tracker := NewIBTPIndexTracker()
+	tracker.Init(&Interchain{ID: testFromService, InterchainCounter: map[string]uint64{testToService: 2}})
+	require.Equal(t, uint64(3), tracker.NextIndex(testFromService, testToService))
+
+	require.False(t, tracker.Observe(ibtp(2, pb.IBTP_INTERCHAIN)))
+	require.False(t, tracker.Observe(ibtp(3, pb.IBTP_RECEIPT_SUCCESS)))
+	for _, index := range []uint64{3, 5, 8, 12} {
+		require.True(t, tracker.Observe(ibtp(index, pb.IBTP_INTERCHAIN)))
+	}
+	require.False(t, tracker.Observe(ibtp(5, pb.IBTP_INTERCHAIN)))
+	require.Equal(t, uint64(4), tracker.NextIndex(testFromService, testToService))
+
+	meta := &Interchain{ID: testFromService, InterchainCounter: map[string]uint64{
+		testToService:       10,
+		"1356:appchain3:s3": 2,
+	}}
+	require.Equal(t, []IBTPGap{
+		{From: testFromService, To: testToService, Begin: 4, End: 4},
+		{From: testFromService, To: testToService, Begin: 6, End: 7},
+		{From: testFromService, To: testToService, Begin: 9, End: 10},
+		{From: testFromService, To: "1356:appchain3:s3", Begin: 1, End: 2},
+	}, tracker.Gaps(meta))
+
+	tracker.Observe(ibtp(4, pb.IBTP_INTERCHAIN))
+	require.Equal(t, uint64(6), tracker.NextIndex(testFromService, testToService))
+	tracker.Init(meta)
+	require.Equal(t, uint64(11), tracker.NextIndex(testFromService, testToService))
+	require.Empty(t, tracker.Gaps(meta))
+}
@@ -0,0 +1,114 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// CallInfo describes a call of the client to BitXHub for interceptors.
+type CallInfo struct {
+	// Method is the name of the called ChainBroker method, like "GetReceipt".
+	Method string
+	// Node is the address of the node the call is sent to.
+	Node string
+	// Attempt counts the attempts of the call from 1, as retried by the
+	// RetryPolicy of the client.
+	Attempt int
+	// Stream is set if the call opens a stream, like a subscription. The call
+	// ends once the stream is open.
+	Stream bool
+}
+
+// Invoker makes a call to BitXHub. The error is an *RPCError if the node
+// failed the call.
+type Invoker func(ctx context.Context, info *CallInfo) error
+
+// Interceptor intercepts every call of the client to BitXHub, such as
+//
+//	logging := func(ctx context.Context, info *rpcx.CallInfo, next rpcx.Invoker) error {
+//		start := time.Now()
+//		err := next(ctx, info)
+//		log.Printf("%s on %s took %s: %v", info.Method, info.Node, time.Since(start), err)
+//		return err
+//	}
+//
+// It proceeds with the call by calling next, with a ctx it may extend, like
+// with the outgoing metadata of auth headers, or fails the call without
+// calling next. ctx holds the account metadata of the client, and the timeout
+// of the attempt unless the call opens a stream.
+type Interceptor func(ctx context.Context, info *CallInfo, next Invoker) error
+
+// chainInterceptors returns the invoker calling interceptors in order around
+// invoker, the first one outermost.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, info *CallInfo) error {
+			return interceptor(ctx, info, next)
+		}
+	}
+	return invoker
+}
+
+// invoke makes an attempt of a call to BitXHub through the interceptors of the
+// client: it bounds ctx with timeout unless it is 0, sets the account metadata,
+// picks a node, and classifies the error of call.
+func (cli *ChainClient) invoke(ctx context.Context, info *CallInfo, timeout time.Duration, call func(ctx context.Context, client *grpcClient) error) error {
+	return cli.invokeOn(ctx, info, timeout, cli.pool.getClient, call)
+}
+
+// invokeOn is invoke on the node of the client returned by getClient.
+func (cli *ChainClient) invokeOn(ctx context.Context, info *CallInfo, timeout time.Duration, getClient func(ctx context.Context) (*grpcClient, error), call func(ctx context.Context, client *grpcClient) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = withDefaultTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ctx, err := cli.SetCtxMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("set ctx metadata err: %v", err)
+	}
+
+	grpcClient, err := getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if grpcClient.node != nil {
+		info.Node = grpcClient.node.info.Addr
+	}
+	return chainInterceptors(cli.interceptors, func(ctx context.Context, info *CallInfo) error {
+		return grpcClient.rpcError(info.Method, call(ctx, grpcClient))
+	})(ctx, info)
+}
+
+// call calls method of BitXHub through invoke, retried with the policy of the
+// client. timeout bounds every attempt unless the policy or the caller sets
+// another one.
+func (cli *ChainClient) call(ctx context.Context, method string, timeout time.Duration, invoke func(ctx context.Context, broker pb.ChainBrokerClient) error) error {
+	return cli.retry(ctx, cli.retryPolicy, func(ctx context.Context, attempt int) error {
+		return cli.callOnce(ctx, method, attempt, timeout, invoke)
+	})
+}
+
+// callOnce makes the given attempt of call.
+func (cli *ChainClient) callOnce(ctx context.Context, method string, attempt int, timeout time.Duration, invoke func(ctx context.Context, broker pb.ChainBrokerClient) error) error {
+	if cli.retryPolicy.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = withClientTimeout(ctx, cli.retryPolicy.AttemptTimeout)
+		defer cancel()
+	}
+	return cli.invoke(ctx, &CallInfo{Method: method, Attempt: attempt}, timeout, func(ctx context.Context, client *grpcClient) error {
+		return invoke(ctx, client.broker)
+	})
+}
+
+// openStream opens a stream of method with open, which is given the client of
+// the node so that the errors of the stream can be classified. The stream
+// lives as long as ctx.
+func (cli *ChainClient) openStream(ctx context.Context, method string, open func(ctx context.Context, client *grpcClient) error) error {
+	return cli.invoke(ctx, &CallInfo{Method: method, Attempt: 1, Stream: true}, 0, open)
+}
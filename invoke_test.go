@@ -0,0 +1,87 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestChainInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) Interceptor {
+		return func(ctx context.Context, info *CallInfo, next Invoker) error {
+			calls = append(calls, name)
+			err := next(ctx, info)
+			calls = append(calls, name)
+			return err
+		}
+	}
+	invoker := chainInterceptors([]Interceptor{interceptor("a"), interceptor("b")}, func(ctx context.Context, info *CallInfo) error {
+		calls = append(calls, info.Method)
+		return nil
+	})
+	require.Nil(t, invoker(context.Background(), &CallInfo{Method: "GetChainID"}))
+	require.Equal(t, []string{"a", "b", "GetChainID", "b", "a"}, calls)
+}
+
+func TestChainClient_Interceptors(t *testing.T) {
+	var infos []CallInfo
+	record := func(ctx context.Context, info *CallInfo, next Invoker) error {
+		infos = append(infos, *info)
+		return next(metadata.AppendToOutgoingContext(ctx, "token", "secret"), info)
+	}
+	errLimited := errors.New("rate limited")
+	limit := func(ctx context.Context, info *CallInfo, next Invoker) error {
+		if info.Method == "GetTPS" {
+			return errLimited
+		}
+		return next(ctx, info)
+	}
+	// the grpc interceptors passed to dialing see the headers set by the interceptors
+	var tokens []string
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		tokens = append(tokens, md.Get("token")...)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	var streams []string
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streams = append(streams, method)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	broker, cli, _ := newBrokerClient(t, nil,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2}),
+		WithInterceptors(record, limit),
+		WithUnaryInterceptors(unary),
+		WithStreamInterceptors(stream),
+	)
+
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Code: codes.Internal, Times: 1})
+	_, err := cli.GetChainID()
+	require.Nil(t, err)
+	require.Equal(t, []CallInfo{
+		{Method: "GetChainID", Node: broker.Addr(), Attempt: 1},
+		{Method: "GetChainID", Node: broker.Addr(), Attempt: 2},
+	}, infos)
+	require.Equal(t, []string{"secret", "secret"}, tokens)
+
+	_, err = cli.GetTPS(1, 2)
+	require.ErrorIs(t, err, errLimited)
+	require.Len(t, tokens, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan *pb.BlockHeader, 1)
+	require.Nil(t, cli.GetBlockHeader(ctx, 1, 1, ch))
+	<-ch
+	require.Equal(t, CallInfo{Method: "GetBlockHeader", Node: broker.Addr(), Attempt: 1, Stream: true}, infos[len(infos)-1])
+	require.Equal(t, []string{"/pb.ChainBroker/GetBlockHeader"}, streams)
+}
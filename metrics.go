@@ -0,0 +1,84 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Metrics receives the measurements of a client, such as PrometheusMetrics.
+// Its methods are called concurrently and must not block.
+type Metrics interface {
+	// ObserveCall observes an attempt of a call of method to BitXHub, which
+	// took duration and failed with err if not nil.
+	ObserveCall(method string, duration time.Duration, err error)
+	// DialFailed counts a failure of dialing node.
+	DialFailed(node string)
+	// ConnectionOpened and ConnectionClosed count the open grpc channels to
+	// node.
+	ConnectionOpened(node string)
+	ConnectionClosed(node string)
+	// SubscriptionOpened and SubscriptionClosed count the active
+	// subscriptions of typ, like "block" or "audit_node".
+	SubscriptionOpened(typ string)
+	SubscriptionClosed(typ string)
+	// MessageReceived counts a message received by a subscription of typ.
+	MessageReceived(typ string)
+	// SubscriptionReconnected counts a resubscription of a resumable
+	// subscription of typ after it broke.
+	SubscriptionReconnected(typ string)
+	// ObserveReceiptWait observes a wait for a receipt, which took duration
+	// and failed with err if not nil.
+	ObserveReceiptWait(duration time.Duration, err error)
+}
+
+// nopMetrics is the Metrics of a client without WithMetrics.
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveCall(string, time.Duration, error) {}
+func (nopMetrics) DialFailed(string)                        {}
+func (nopMetrics) ConnectionOpened(string)                  {}
+func (nopMetrics) ConnectionClosed(string)                  {}
+func (nopMetrics) SubscriptionOpened(string)                {}
+func (nopMetrics) SubscriptionClosed(string)                {}
+func (nopMetrics) MessageReceived(string)                   {}
+func (nopMetrics) SubscriptionReconnected(string)           {}
+func (nopMetrics) ObserveReceiptWait(time.Duration, error)  {}
+
+// metricsInterceptor observes every attempt of the calls of the client.
+func metricsInterceptor(metrics Metrics) Interceptor {
+	return func(ctx context.Context, info *CallInfo, next Invoker) error {
+		start := time.Now()
+		err := next(ctx, info)
+		metrics.ObserveCall(info.Method, time.Since(start), err)
+		return err
+	}
+}
+
+// ErrorClass returns the class of err for metrics and logs: "ok" if err is
+// nil, otherwise the name of the sentinel err matches, like "not_found" for
+// ErrNotFound or "network" for ErrBrokenNetwork, and "other" if none.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrNonceTooLow):
+		return "nonce_too_low"
+	case errors.Is(err, ErrInsufficientBalance):
+		return "insufficient_balance"
+	case errors.Is(err, ErrPermissionDenied):
+		return "permission_denied"
+	case errors.Is(err, ErrReconstruct):
+		return "invalid"
+	case errors.Is(err, ErrRecoverable):
+		return "network"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,151 @@
+package rpcx
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics is the Metrics of a client as a prometheus.Collector:
+//
+//	metrics := rpcx.NewPrometheusMetrics("pier")
+//	prometheus.MustRegister(metrics)
+//	cli, err := rpcx.New(rpcx.WithMetrics(metrics), ...)
+//
+// Calls are labeled by method and error class (see ErrorClass), the pool by
+// node address and subscriptions by type.
+type PrometheusMetrics struct {
+	calls         *prometheus.CounterVec
+	callDuration  *prometheus.HistogramVec
+	dialFailures  *prometheus.CounterVec
+	connections   *prometheus.GaugeVec
+	subscriptions *prometheus.GaugeVec
+	messages      *prometheus.CounterVec
+	reconnects    *prometheus.CounterVec
+	receiptWait   *prometheus.HistogramVec
+}
+
+var (
+	_ Metrics              = (*PrometheusMetrics)(nil)
+	_ prometheus.Collector = (*PrometheusMetrics)(nil)
+)
+
+// NewPrometheusMetrics creates the metrics, with names prefixed by namespace
+// if not empty, like "pier_bitxhub_client_calls_total".
+func NewPrometheusMetrics(namespace string) *PrometheusMetrics {
+	const subsystem = "bitxhub_client"
+	return &PrometheusMetrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "calls_total",
+			Help:      "Attempts of calls to BitXHub by method and error class.",
+		}, []string{"method", "class"}),
+		callDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "call_duration_seconds",
+			Help:      "Latency of the attempts of calls to BitXHub by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		dialFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dial_failures_total",
+			Help:      "Failures of dialing BitXHub nodes by node.",
+		}, []string{"node"}),
+		connections: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "connections",
+			Help:      "Open grpc channels of the connection pool by node.",
+		}, []string{"node"}),
+		subscriptions: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "subscriptions",
+			Help:      "Active subscriptions by type.",
+		}, []string{"type"}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "subscription_messages_total",
+			Help:      "Messages received by subscriptions by type.",
+		}, []string{"type"}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "subscription_reconnects_total",
+			Help:      "Resubscriptions of broken resumable subscriptions by type.",
+		}, []string{"type"}),
+		receiptWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "receipt_wait_seconds",
+			Help:      "Time waited for receipts by error class.",
+			Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"class"}),
+	}
+}
+
+func (m *PrometheusMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.calls,
+		m.callDuration,
+		m.dialFailures,
+		m.connections,
+		m.subscriptions,
+		m.messages,
+		m.reconnects,
+		m.receiptWait,
+	}
+}
+
+func (m *PrometheusMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+func (m *PrometheusMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *PrometheusMetrics) ObserveCall(method string, duration time.Duration, err error) {
+	m.calls.WithLabelValues(method, ErrorClass(err)).Inc()
+	m.callDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+func (m *PrometheusMetrics) DialFailed(node string) {
+	m.dialFailures.WithLabelValues(node).Inc()
+}
+
+func (m *PrometheusMetrics) ConnectionOpened(node string) {
+	m.connections.WithLabelValues(node).Inc()
+}
+
+func (m *PrometheusMetrics) ConnectionClosed(node string) {
+	m.connections.WithLabelValues(node).Dec()
+}
+
+func (m *PrometheusMetrics) SubscriptionOpened(typ string) {
+	m.subscriptions.WithLabelValues(typ).Inc()
+}
+
+func (m *PrometheusMetrics) SubscriptionClosed(typ string) {
+	m.subscriptions.WithLabelValues(typ).Dec()
+}
+
+func (m *PrometheusMetrics) MessageReceived(typ string) {
+	m.messages.WithLabelValues(typ).Inc()
+}
+
+func (m *PrometheusMetrics) SubscriptionReconnected(typ string) {
+	m.reconnects.WithLabelValues(typ).Inc()
+}
+
+func (m *PrometheusMetrics) ObserveReceiptWait(duration time.Duration, err error) {
+	m.receiptWait.WithLabelValues(ErrorClass(err)).Observe(duration.Seconds())
+}
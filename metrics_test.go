@@ -0,0 +1,118 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestErrorClass(t *testing.T) {
+	for err, class := range map[error]string{
+		nil:                      "ok",
+		context.Canceled:         "canceled",
+		context.DeadlineExceeded: "timeout",
+		ErrTimeout:               "timeout",
+		ErrNotFound:              "not_found",
+		ErrNonceTooLow:           "nonce_too_low",
+		ErrInsufficientBalance:   "insufficient_balance",
+		ErrPermissionDenied:      "permission_denied",
+		ErrSignTx:                "invalid",
+		ErrBrokenNetwork:         "network",
+		fmt.Errorf("unknown"):    "other",
+		fmt.Errorf("wait receipt: %w", context.DeadlineExceeded): "timeout",
+	} {
+		require.Equal(t, class, ErrorClass(err), "%v", err)
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	metrics := NewPrometheusMetrics("test")
+	require.Nil(t, prometheus.NewRegistry().Register(metrics))
+	broker, cli, from := newBrokerClient(t, nil,
+		WithMetrics(metrics),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithResubscribeBackoff(10*time.Millisecond, 10*time.Millisecond),
+	)
+
+	_, err := cli.GetChainID()
+	require.Nil(t, err)
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Code: codes.NotFound, Times: 1})
+	_, err = cli.GetChainID()
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("GetChainID", "ok")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("GetChainID", "not_found")))
+	require.Equal(t, 1, testutil.CollectAndCount(metrics.callDuration))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.connections.WithLabelValues(broker.Addr())))
+
+	tx, err := NewTxBuilder(from).Transfer(types.NewAddressByStr("0x0000000000000000000000000000000000000001"), big.NewInt(1)).Build()
+	require.Nil(t, err)
+	_, err = cli.SendTransactionWithReceipt(tx, nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, testutil.CollectAndCount(metrics.receiptWait))
+
+	// a subscription counts its messages
+	ctx, cancel := context.WithCancel(context.Background())
+	headers, _ := cli.SubscribeBlockHeaders(ctx)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.subscriptions.WithLabelValues("block_header")))
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+	<-headers
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.messages.WithLabelValues("block_header")))
+	cancel()
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.subscriptions.WithLabelValues("block_header")) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// a resumed subscription counts its reconnects
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	blocks := cli.SubscribeBlocksFrom(ctx, 1)
+	for i := uint64(0); i < broker.Height(); i++ {
+		<-blocks
+	}
+	broker.DropStreams()
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+	<-blocks
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.reconnects.WithLabelValues("block")))
+
+	require.Nil(t, cli.pool.Close())
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(metrics.connections.WithLabelValues(broker.Addr())) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPrometheusMetrics_DialFailure(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	addr := lis.Addr().String()
+	require.Nil(t, lis.Close())
+
+	metrics := NewPrometheusMetrics("")
+	pool, err := NewPool(&config{
+		nodesInfo:    []*NodeInfo{{Addr: addr}},
+		logger:       logrus.New(),
+		timeoutLimit: 100 * time.Millisecond,
+		poolSize:     1,
+		nodeSelector: RandomSelector(),
+		metrics:      metrics,
+	})
+	require.Nil(t, err)
+	defer pool.Close()
+
+	_, err = pool.clientOf(context.Background(), pool.health.nodes[0])
+	require.ErrorIs(t, err, ErrBrokenNetwork)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.dialFailures.WithLabelValues(addr)))
+}
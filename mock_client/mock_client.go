@@ -6,6 +6,7 @@ package mock_client
 
 import (
 	context "context"
+	big "math/big"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -53,6 +54,21 @@ func (mr *MockClientMockRecorder) CheckMasterPier(address interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMasterPier", reflect.TypeOf((*MockClient)(nil).CheckMasterPier), address)
 }
 
+// CheckMasterPierCtx mocks base method.
+func (m *MockClient) CheckMasterPierCtx(ctx context.Context, address string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMasterPierCtx", ctx, address)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckMasterPierCtx indicates an expected call of CheckMasterPierCtx.
+func (mr *MockClientMockRecorder) CheckMasterPierCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMasterPierCtx", reflect.TypeOf((*MockClient)(nil).CheckMasterPierCtx), ctx, address)
+}
+
 // DeployContract mocks base method.
 func (m *MockClient) DeployContract(contract []byte, opts *rpcx.TransactOpts) (*types.Address, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +84,51 @@ func (mr *MockClientMockRecorder) DeployContract(contract, opts interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployContract", reflect.TypeOf((*MockClient)(nil).DeployContract), contract, opts)
 }
 
+// DeployContractCtx mocks base method.
+func (m *MockClient) DeployContractCtx(ctx context.Context, contract []byte, opts *rpcx.TransactOpts) (*types.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployContractCtx", ctx, contract, opts)
+	ret0, _ := ret[0].(*types.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeployContractCtx indicates an expected call of DeployContractCtx.
+func (mr *MockClientMockRecorder) DeployContractCtx(ctx, contract, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployContractCtx", reflect.TypeOf((*MockClient)(nil).DeployContractCtx), ctx, contract, opts)
+}
+
+// ExportUnsignedTx mocks base method.
+func (m *MockClient) ExportUnsignedTx(tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*rpcx.TxEnvelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUnsignedTx", tx, opts)
+	ret0, _ := ret[0].(*rpcx.TxEnvelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUnsignedTx indicates an expected call of ExportUnsignedTx.
+func (mr *MockClientMockRecorder) ExportUnsignedTx(tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUnsignedTx", reflect.TypeOf((*MockClient)(nil).ExportUnsignedTx), tx, opts)
+}
+
+// ExportUnsignedTxCtx mocks base method.
+func (m *MockClient) ExportUnsignedTxCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*rpcx.TxEnvelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUnsignedTxCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(*rpcx.TxEnvelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUnsignedTxCtx indicates an expected call of ExportUnsignedTxCtx.
+func (mr *MockClientMockRecorder) ExportUnsignedTxCtx(ctx, tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUnsignedTxCtx", reflect.TypeOf((*MockClient)(nil).ExportUnsignedTxCtx), ctx, tx, opts)
+}
+
 // GenerateContractTx mocks base method.
 func (m *MockClient) GenerateContractTx(vmType pb.TransactionData_VMType, address *types.Address, method string, args ...*pb.Arg) (*pb.BxhTransaction, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +164,21 @@ func (mr *MockClientMockRecorder) GenerateIBTPTx(ibtp interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateIBTPTx", reflect.TypeOf((*MockClient)(nil).GenerateIBTPTx), ibtp)
 }
 
+// GetAccount mocks base method.
+func (m *MockClient) GetAccount(address string) (*rpcx.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", address)
+	ret0, _ := ret[0].(*rpcx.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockClientMockRecorder) GetAccount(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockClient)(nil).GetAccount), address)
+}
+
 // GetAccountBalance mocks base method.
 func (m *MockClient) GetAccountBalance(address string) (*pb.Response, error) {
 	m.ctrl.T.Helper()
@@ -118,6 +194,126 @@ func (mr *MockClientMockRecorder) GetAccountBalance(address interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockClient)(nil).GetAccountBalance), address)
 }
 
+// GetAccountBalanceCtx mocks base method.
+func (m *MockClient) GetAccountBalanceCtx(ctx context.Context, address string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceCtx", ctx, address)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceCtx indicates an expected call of GetAccountBalanceCtx.
+func (mr *MockClientMockRecorder) GetAccountBalanceCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceCtx", reflect.TypeOf((*MockClient)(nil).GetAccountBalanceCtx), ctx, address)
+}
+
+// GetAccountCtx mocks base method.
+func (m *MockClient) GetAccountCtx(ctx context.Context, address string) (*rpcx.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountCtx", ctx, address)
+	ret0, _ := ret[0].(*rpcx.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountCtx indicates an expected call of GetAccountCtx.
+func (mr *MockClientMockRecorder) GetAccountCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCtx", reflect.TypeOf((*MockClient)(nil).GetAccountCtx), ctx, address)
+}
+
+// GetAppchain mocks base method.
+func (m *MockClient) GetAppchain(id string) (*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppchain", id)
+	ret0, _ := ret[0].(*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppchain indicates an expected call of GetAppchain.
+func (mr *MockClientMockRecorder) GetAppchain(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppchain", reflect.TypeOf((*MockClient)(nil).GetAppchain), id)
+}
+
+// GetAppchainCtx mocks base method.
+func (m *MockClient) GetAppchainCtx(ctx context.Context, id string) (*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppchainCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppchainCtx indicates an expected call of GetAppchainCtx.
+func (mr *MockClientMockRecorder) GetAppchainCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppchainCtx", reflect.TypeOf((*MockClient)(nil).GetAppchainCtx), ctx, id)
+}
+
+// GetBalance mocks base method.
+func (m *MockClient) GetBalance(address string) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", address)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockClientMockRecorder) GetBalance(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockClient)(nil).GetBalance), address)
+}
+
+// GetBalanceCtx mocks base method.
+func (m *MockClient) GetBalanceCtx(ctx context.Context, address string) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceCtx", ctx, address)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceCtx indicates an expected call of GetBalanceCtx.
+func (mr *MockClientMockRecorder) GetBalanceCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceCtx", reflect.TypeOf((*MockClient)(nil).GetBalanceCtx), ctx, address)
+}
+
+// GetBalances mocks base method.
+func (m *MockClient) GetBalances(addresses []string) (map[string]*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", addresses)
+	ret0, _ := ret[0].(map[string]*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockClientMockRecorder) GetBalances(addresses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockClient)(nil).GetBalances), addresses)
+}
+
+// GetBalancesCtx mocks base method.
+func (m *MockClient) GetBalancesCtx(ctx context.Context, addresses []string) (map[string]*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalancesCtx", ctx, addresses)
+	ret0, _ := ret[0].(map[string]*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalancesCtx indicates an expected call of GetBalancesCtx.
+func (mr *MockClientMockRecorder) GetBalancesCtx(ctx, addresses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalancesCtx", reflect.TypeOf((*MockClient)(nil).GetBalancesCtx), ctx, addresses)
+}
+
 // GetBlock mocks base method.
 func (m *MockClient) GetBlock(value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +329,21 @@ func (mr *MockClientMockRecorder) GetBlock(value, blockType, fullTx interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockClient)(nil).GetBlock), value, blockType, fullTx)
 }
 
+// GetBlockCtx mocks base method.
+func (m *MockClient) GetBlockCtx(ctx context.Context, value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCtx", ctx, value, blockType, fullTx)
+	ret0, _ := ret[0].(*pb.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCtx indicates an expected call of GetBlockCtx.
+func (mr *MockClientMockRecorder) GetBlockCtx(ctx, value, blockType, fullTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCtx", reflect.TypeOf((*MockClient)(nil).GetBlockCtx), ctx, value, blockType, fullTx)
+}
+
 // GetBlockHeader mocks base method.
 func (m *MockClient) GetBlockHeader(ctx context.Context, begin, end uint64, ch chan<- *pb.BlockHeader) error {
 	m.ctrl.T.Helper()
@@ -162,6 +373,21 @@ func (mr *MockClientMockRecorder) GetBlocks(start, end, fullTx interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockClient)(nil).GetBlocks), start, end, fullTx)
 }
 
+// GetBlocksCtx mocks base method.
+func (m *MockClient) GetBlocksCtx(ctx context.Context, start, end uint64, fullTx bool) (*pb.GetBlocksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksCtx", ctx, start, end, fullTx)
+	ret0, _ := ret[0].(*pb.GetBlocksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksCtx indicates an expected call of GetBlocksCtx.
+func (mr *MockClientMockRecorder) GetBlocksCtx(ctx, start, end, fullTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksCtx", reflect.TypeOf((*MockClient)(nil).GetBlocksCtx), ctx, start, end, fullTx)
+}
+
 // GetChainID mocks base method.
 func (m *MockClient) GetChainID() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +403,21 @@ func (mr *MockClientMockRecorder) GetChainID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainID", reflect.TypeOf((*MockClient)(nil).GetChainID))
 }
 
+// GetChainIDCtx mocks base method.
+func (m *MockClient) GetChainIDCtx(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainIDCtx", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainIDCtx indicates an expected call of GetChainIDCtx.
+func (mr *MockClientMockRecorder) GetChainIDCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainIDCtx", reflect.TypeOf((*MockClient)(nil).GetChainIDCtx), ctx)
+}
+
 // GetChainMeta mocks base method.
 func (m *MockClient) GetChainMeta() (*pb.ChainMeta, error) {
 	m.ctrl.T.Helper()
@@ -192,6 +433,21 @@ func (mr *MockClientMockRecorder) GetChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainMeta", reflect.TypeOf((*MockClient)(nil).GetChainMeta))
 }
 
+// GetChainMetaCtx mocks base method.
+func (m *MockClient) GetChainMetaCtx(ctx context.Context) (*pb.ChainMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainMetaCtx", ctx)
+	ret0, _ := ret[0].(*pb.ChainMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainMetaCtx indicates an expected call of GetChainMetaCtx.
+func (mr *MockClientMockRecorder) GetChainMetaCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainMetaCtx", reflect.TypeOf((*MockClient)(nil).GetChainMetaCtx), ctx)
+}
+
 // GetChainStatus mocks base method.
 func (m *MockClient) GetChainStatus() (*pb.Response, error) {
 	m.ctrl.T.Helper()
@@ -207,447 +463,2651 @@ func (mr *MockClientMockRecorder) GetChainStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainStatus", reflect.TypeOf((*MockClient)(nil).GetChainStatus))
 }
 
-// GetInterchainTxWrappers mocks base method.
-func (m *MockClient) GetInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64, ch chan<- *pb.InterchainTxWrappers) error {
+// GetChainStatusCtx mocks base method.
+func (m *MockClient) GetChainStatusCtx(ctx context.Context) (*pb.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInterchainTxWrappers", ctx, pid, begin, end, ch)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetChainStatusCtx", ctx)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetInterchainTxWrappers indicates an expected call of GetInterchainTxWrappers.
-func (mr *MockClientMockRecorder) GetInterchainTxWrappers(ctx, pid, begin, end, ch interface{}) *gomock.Call {
+// GetChainStatusCtx indicates an expected call of GetChainStatusCtx.
+func (mr *MockClientMockRecorder) GetChainStatusCtx(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainTxWrappers", reflect.TypeOf((*MockClient)(nil).GetInterchainTxWrappers), ctx, pid, begin, end, ch)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainStatusCtx", reflect.TypeOf((*MockClient)(nil).GetChainStatusCtx), ctx)
 }
 
-// GetMultiSigns mocks base method.
-func (m *MockClient) GetMultiSigns(id string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
+// GetIBTPStatus mocks base method.
+func (m *MockClient) GetIBTPStatus(id string) (pb.TransactionStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMultiSigns", id, typ)
-	ret0, _ := ret[0].(*pb.SignResponse)
+	ret := m.ctrl.Call(m, "GetIBTPStatus", id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMultiSigns indicates an expected call of GetMultiSigns.
-func (mr *MockClientMockRecorder) GetMultiSigns(id, typ interface{}) *gomock.Call {
+// GetIBTPStatus indicates an expected call of GetIBTPStatus.
+func (mr *MockClientMockRecorder) GetIBTPStatus(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiSigns", reflect.TypeOf((*MockClient)(nil).GetMultiSigns), id, typ)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIBTPStatus", reflect.TypeOf((*MockClient)(nil).GetIBTPStatus), id)
 }
 
-// GetNetworkMeta mocks base method.
-func (m *MockClient) GetNetworkMeta() (*pb.Response, error) {
+// GetIBTPStatusCtx mocks base method.
+func (m *MockClient) GetIBTPStatusCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetNetworkMeta")
-	ret0, _ := ret[0].(*pb.Response)
+	ret := m.ctrl.Call(m, "GetIBTPStatusCtx", ctx, id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetNetworkMeta indicates an expected call of GetNetworkMeta.
-func (mr *MockClientMockRecorder) GetNetworkMeta() *gomock.Call {
+// GetIBTPStatusCtx indicates an expected call of GetIBTPStatusCtx.
+func (mr *MockClientMockRecorder) GetIBTPStatusCtx(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMeta", reflect.TypeOf((*MockClient)(nil).GetNetworkMeta))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIBTPStatusCtx", reflect.TypeOf((*MockClient)(nil).GetIBTPStatusCtx), ctx, id)
 }
 
-// GetPendingNonceByAccount mocks base method.
-func (m *MockClient) GetPendingNonceByAccount(account string) (uint64, error) {
+// GetInterchain mocks base method.
+func (m *MockClient) GetInterchain(id string) (*rpcx.Interchain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPendingNonceByAccount", account)
-	ret0, _ := ret[0].(uint64)
+	ret := m.ctrl.Call(m, "GetInterchain", id)
+	ret0, _ := ret[0].(*rpcx.Interchain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPendingNonceByAccount indicates an expected call of GetPendingNonceByAccount.
-func (mr *MockClientMockRecorder) GetPendingNonceByAccount(account interface{}) *gomock.Call {
+// GetInterchain indicates an expected call of GetInterchain.
+func (mr *MockClientMockRecorder) GetInterchain(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNonceByAccount", reflect.TypeOf((*MockClient)(nil).GetPendingNonceByAccount), account)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchain", reflect.TypeOf((*MockClient)(nil).GetInterchain), id)
 }
 
-// GetReceipt mocks base method.
-func (m *MockClient) GetReceipt(hash string) (*pb.Receipt, error) {
+// GetInterchainCtx mocks base method.
+func (m *MockClient) GetInterchainCtx(ctx context.Context, id string) (*rpcx.Interchain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceipt", hash)
-	ret0, _ := ret[0].(*pb.Receipt)
+	ret := m.ctrl.Call(m, "GetInterchainCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Interchain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReceipt indicates an expected call of GetReceipt.
-func (mr *MockClientMockRecorder) GetReceipt(hash interface{}) *gomock.Call {
+// GetInterchainCtx indicates an expected call of GetInterchainCtx.
+func (mr *MockClientMockRecorder) GetInterchainCtx(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockClient)(nil).GetReceipt), hash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainCtx", reflect.TypeOf((*MockClient)(nil).GetInterchainCtx), ctx, id)
 }
 
-// GetTPS mocks base method.
-func (m *MockClient) GetTPS(begin, end uint64) (uint64, error) {
+// GetInterchainMeta mocks base method.
+func (m *MockClient) GetInterchainMeta(serviceID string) (*rpcx.Interchain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTPS", begin, end)
-	ret0, _ := ret[0].(uint64)
+	ret := m.ctrl.Call(m, "GetInterchainMeta", serviceID)
+	ret0, _ := ret[0].(*rpcx.Interchain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTPS indicates an expected call of GetTPS.
-func (mr *MockClientMockRecorder) GetTPS(begin, end interface{}) *gomock.Call {
+// GetInterchainMeta indicates an expected call of GetInterchainMeta.
+func (mr *MockClientMockRecorder) GetInterchainMeta(serviceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTPS", reflect.TypeOf((*MockClient)(nil).GetTPS), begin, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainMeta", reflect.TypeOf((*MockClient)(nil).GetInterchainMeta), serviceID)
 }
 
-// GetTransaction mocks base method.
-func (m *MockClient) GetTransaction(hash string) (*pb.GetTransactionResponse, error) {
+// GetInterchainMetaCtx mocks base method.
+func (m *MockClient) GetInterchainMetaCtx(ctx context.Context, serviceID string) (*rpcx.Interchain, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransaction", hash)
-	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret := m.ctrl.Call(m, "GetInterchainMetaCtx", ctx, serviceID)
+	ret0, _ := ret[0].(*rpcx.Interchain)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransaction indicates an expected call of GetTransaction.
-func (mr *MockClientMockRecorder) GetTransaction(hash interface{}) *gomock.Call {
+// GetInterchainMetaCtx indicates an expected call of GetInterchainMetaCtx.
+func (mr *MockClientMockRecorder) GetInterchainMetaCtx(ctx, serviceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockClient)(nil).GetTransaction), hash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainMetaCtx", reflect.TypeOf((*MockClient)(nil).GetInterchainMetaCtx), ctx, serviceID)
 }
 
-// GetTransactionByBlockHashAndIndex mocks base method.
-func (m *MockClient) GetTransactionByBlockHashAndIndex(blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
+// GetInterchainTxWrappers mocks base method.
+func (m *MockClient) GetInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64, ch chan<- *pb.InterchainTxWrappers) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionByBlockHashAndIndex", blockHash, index)
-	ret0, _ := ret[0].(*pb.GetTransactionResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetInterchainTxWrappers", ctx, pid, begin, end, ch)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTransactionByBlockHashAndIndex indicates an expected call of GetTransactionByBlockHashAndIndex.
-func (mr *MockClientMockRecorder) GetTransactionByBlockHashAndIndex(blockHash, index interface{}) *gomock.Call {
+// GetInterchainTxWrappers indicates an expected call of GetInterchainTxWrappers.
+func (mr *MockClientMockRecorder) GetInterchainTxWrappers(ctx, pid, begin, end, ch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockHashAndIndex", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockHashAndIndex), blockHash, index)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainTxWrappers", reflect.TypeOf((*MockClient)(nil).GetInterchainTxWrappers), ctx, pid, begin, end, ch)
 }
 
-// GetTransactionByBlockNumberAndIndex mocks base method.
-func (m *MockClient) GetTransactionByBlockNumberAndIndex(blockNum, index uint64) (*pb.GetTransactionResponse, error) {
+// GetMultiSigns mocks base method.
+func (m *MockClient) GetMultiSigns(id string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionByBlockNumberAndIndex", blockNum, index)
-	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret := m.ctrl.Call(m, "GetMultiSigns", id, typ)
+	ret0, _ := ret[0].(*pb.SignResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransactionByBlockNumberAndIndex indicates an expected call of GetTransactionByBlockNumberAndIndex.
-func (mr *MockClientMockRecorder) GetTransactionByBlockNumberAndIndex(blockNum, index interface{}) *gomock.Call {
+// GetMultiSigns indicates an expected call of GetMultiSigns.
+func (mr *MockClientMockRecorder) GetMultiSigns(id, typ interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockNumberAndIndex", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockNumberAndIndex), blockNum, index)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiSigns", reflect.TypeOf((*MockClient)(nil).GetMultiSigns), id, typ)
 }
 
-// GetTssSigns mocks base method.
-func (m *MockClient) GetTssSigns(id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
+// GetMultiSignsCtx mocks base method.
+func (m *MockClient) GetMultiSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTssSigns", id, typ, extra)
+	ret := m.ctrl.Call(m, "GetMultiSignsCtx", ctx, id, typ)
 	ret0, _ := ret[0].(*pb.SignResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTssSigns indicates an expected call of GetTssSigns.
-func (mr *MockClientMockRecorder) GetTssSigns(id, typ, extra interface{}) *gomock.Call {
+// GetMultiSignsCtx indicates an expected call of GetMultiSignsCtx.
+func (mr *MockClientMockRecorder) GetMultiSignsCtx(ctx, id, typ interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTssSigns", reflect.TypeOf((*MockClient)(nil).GetTssSigns), id, typ, extra)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiSignsCtx", reflect.TypeOf((*MockClient)(nil).GetMultiSignsCtx), ctx, id, typ)
 }
 
-// GetValidators mocks base method.
-func (m *MockClient) GetValidators() (*pb.Response, error) {
+// GetNetworkMeta mocks base method.
+func (m *MockClient) GetNetworkMeta() (*pb.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValidators")
+	ret := m.ctrl.Call(m, "GetNetworkMeta")
 	ret0, _ := ret[0].(*pb.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetValidators indicates an expected call of GetValidators.
-func (mr *MockClientMockRecorder) GetValidators() *gomock.Call {
+// GetNetworkMeta indicates an expected call of GetNetworkMeta.
+func (mr *MockClientMockRecorder) GetNetworkMeta() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidators", reflect.TypeOf((*MockClient)(nil).GetValidators))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMeta", reflect.TypeOf((*MockClient)(nil).GetNetworkMeta))
 }
 
-// HeartBeat mocks base method.
-func (m *MockClient) HeartBeat(address, index string) (*pb.Response, error) {
+// GetNetworkMetaCtx mocks base method.
+func (m *MockClient) GetNetworkMetaCtx(ctx context.Context) (*pb.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HeartBeat", address, index)
+	ret := m.ctrl.Call(m, "GetNetworkMetaCtx", ctx)
 	ret0, _ := ret[0].(*pb.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HeartBeat indicates an expected call of HeartBeat.
-func (mr *MockClientMockRecorder) HeartBeat(address, index interface{}) *gomock.Call {
+// GetNetworkMetaCtx indicates an expected call of GetNetworkMetaCtx.
+func (mr *MockClientMockRecorder) GetNetworkMetaCtx(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartBeat", reflect.TypeOf((*MockClient)(nil).HeartBeat), address, index)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMetaCtx", reflect.TypeOf((*MockClient)(nil).GetNetworkMetaCtx), ctx)
 }
 
-// IPFSGet mocks base method.
-func (m *MockClient) IPFSGet(path string) (*pb.Response, error) {
+// GetNode mocks base method.
+func (m *MockClient) GetNode(account string) (*rpcx.Node, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IPFSGet", path)
-	ret0, _ := ret[0].(*pb.Response)
+	ret := m.ctrl.Call(m, "GetNode", account)
+	ret0, _ := ret[0].(*rpcx.Node)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IPFSGet indicates an expected call of IPFSGet.
+// GetNode indicates an expected call of GetNode.
+func (mr *MockClientMockRecorder) GetNode(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNode", reflect.TypeOf((*MockClient)(nil).GetNode), account)
+}
+
+// GetNodeCtx mocks base method.
+func (m *MockClient) GetNodeCtx(ctx context.Context, account string) (*rpcx.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeCtx", ctx, account)
+	ret0, _ := ret[0].(*rpcx.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeCtx indicates an expected call of GetNodeCtx.
+func (mr *MockClientMockRecorder) GetNodeCtx(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeCtx", reflect.TypeOf((*MockClient)(nil).GetNodeCtx), ctx, account)
+}
+
+// GetPendingNonceByAccount mocks base method.
+func (m *MockClient) GetPendingNonceByAccount(account string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNonceByAccount", account)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNonceByAccount indicates an expected call of GetPendingNonceByAccount.
+func (mr *MockClientMockRecorder) GetPendingNonceByAccount(account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNonceByAccount", reflect.TypeOf((*MockClient)(nil).GetPendingNonceByAccount), account)
+}
+
+// GetPendingNonceByAccountCtx mocks base method.
+func (m *MockClient) GetPendingNonceByAccountCtx(ctx context.Context, account string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNonceByAccountCtx", ctx, account)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNonceByAccountCtx indicates an expected call of GetPendingNonceByAccountCtx.
+func (mr *MockClientMockRecorder) GetPendingNonceByAccountCtx(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNonceByAccountCtx", reflect.TypeOf((*MockClient)(nil).GetPendingNonceByAccountCtx), ctx, account)
+}
+
+// GetReceipt mocks base method.
+func (m *MockClient) GetReceipt(hash string) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipt", hash)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockClientMockRecorder) GetReceipt(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockClient)(nil).GetReceipt), hash)
+}
+
+// GetReceiptCtx mocks base method.
+func (m *MockClient) GetReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceiptCtx", ctx, hash)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptCtx indicates an expected call of GetReceiptCtx.
+func (mr *MockClientMockRecorder) GetReceiptCtx(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptCtx", reflect.TypeOf((*MockClient)(nil).GetReceiptCtx), ctx, hash)
+}
+
+// GetRole mocks base method.
+func (m *MockClient) GetRole(id string) (*rpcx.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", id)
+	ret0, _ := ret[0].(*rpcx.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockClientMockRecorder) GetRole(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockClient)(nil).GetRole), id)
+}
+
+// GetRoleCtx mocks base method.
+func (m *MockClient) GetRoleCtx(ctx context.Context, id string) (*rpcx.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleCtx indicates an expected call of GetRoleCtx.
+func (mr *MockClientMockRecorder) GetRoleCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleCtx", reflect.TypeOf((*MockClient)(nil).GetRoleCtx), ctx, id)
+}
+
+// GetRule mocks base method.
+func (m *MockClient) GetRule(chainID, ruleAddr string) (*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRule", chainID, ruleAddr)
+	ret0, _ := ret[0].(*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRule indicates an expected call of GetRule.
+func (mr *MockClientMockRecorder) GetRule(chainID, ruleAddr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRule", reflect.TypeOf((*MockClient)(nil).GetRule), chainID, ruleAddr)
+}
+
+// GetRuleCtx mocks base method.
+func (m *MockClient) GetRuleCtx(ctx context.Context, chainID, ruleAddr string) (*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleCtx", ctx, chainID, ruleAddr)
+	ret0, _ := ret[0].(*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleCtx indicates an expected call of GetRuleCtx.
+func (mr *MockClientMockRecorder) GetRuleCtx(ctx, chainID, ruleAddr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleCtx", reflect.TypeOf((*MockClient)(nil).GetRuleCtx), ctx, chainID, ruleAddr)
+}
+
+// GetService mocks base method.
+func (m *MockClient) GetService(chainServiceID string) (*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetService", chainServiceID)
+	ret0, _ := ret[0].(*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetService indicates an expected call of GetService.
+func (mr *MockClientMockRecorder) GetService(chainServiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockClient)(nil).GetService), chainServiceID)
+}
+
+// GetServiceCtx mocks base method.
+func (m *MockClient) GetServiceCtx(ctx context.Context, chainServiceID string) (*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceCtx", ctx, chainServiceID)
+	ret0, _ := ret[0].(*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceCtx indicates an expected call of GetServiceCtx.
+func (mr *MockClientMockRecorder) GetServiceCtx(ctx, chainServiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCtx", reflect.TypeOf((*MockClient)(nil).GetServiceCtx), ctx, chainServiceID)
+}
+
+// GetTPS mocks base method.
+func (m *MockClient) GetTPS(begin, end uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTPS", begin, end)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTPS indicates an expected call of GetTPS.
+func (mr *MockClientMockRecorder) GetTPS(begin, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTPS", reflect.TypeOf((*MockClient)(nil).GetTPS), begin, end)
+}
+
+// GetTPSCtx mocks base method.
+func (m *MockClient) GetTPSCtx(ctx context.Context, begin, end uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTPSCtx", ctx, begin, end)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTPSCtx indicates an expected call of GetTPSCtx.
+func (mr *MockClientMockRecorder) GetTPSCtx(ctx, begin, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTPSCtx", reflect.TypeOf((*MockClient)(nil).GetTPSCtx), ctx, begin, end)
+}
+
+// GetTransaction mocks base method.
+func (m *MockClient) GetTransaction(hash string) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", hash)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockClientMockRecorder) GetTransaction(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockClient)(nil).GetTransaction), hash)
+}
+
+// GetTransactionByBlockHashAndIndex mocks base method.
+func (m *MockClient) GetTransactionByBlockHashAndIndex(blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockHashAndIndex", blockHash, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockHashAndIndex indicates an expected call of GetTransactionByBlockHashAndIndex.
+func (mr *MockClientMockRecorder) GetTransactionByBlockHashAndIndex(blockHash, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockHashAndIndex", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockHashAndIndex), blockHash, index)
+}
+
+// GetTransactionByBlockHashAndIndexCtx mocks base method.
+func (m *MockClient) GetTransactionByBlockHashAndIndexCtx(ctx context.Context, blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockHashAndIndexCtx", ctx, blockHash, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockHashAndIndexCtx indicates an expected call of GetTransactionByBlockHashAndIndexCtx.
+func (mr *MockClientMockRecorder) GetTransactionByBlockHashAndIndexCtx(ctx, blockHash, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockHashAndIndexCtx", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockHashAndIndexCtx), ctx, blockHash, index)
+}
+
+// GetTransactionByBlockNumberAndIndex mocks base method.
+func (m *MockClient) GetTransactionByBlockNumberAndIndex(blockNum, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockNumberAndIndex", blockNum, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockNumberAndIndex indicates an expected call of GetTransactionByBlockNumberAndIndex.
+func (mr *MockClientMockRecorder) GetTransactionByBlockNumberAndIndex(blockNum, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockNumberAndIndex", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockNumberAndIndex), blockNum, index)
+}
+
+// GetTransactionByBlockNumberAndIndexCtx mocks base method.
+func (m *MockClient) GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNum, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockNumberAndIndexCtx", ctx, blockNum, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockNumberAndIndexCtx indicates an expected call of GetTransactionByBlockNumberAndIndexCtx.
+func (mr *MockClientMockRecorder) GetTransactionByBlockNumberAndIndexCtx(ctx, blockNum, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockNumberAndIndexCtx", reflect.TypeOf((*MockClient)(nil).GetTransactionByBlockNumberAndIndexCtx), ctx, blockNum, index)
+}
+
+// GetTransactionCtx mocks base method.
+func (m *MockClient) GetTransactionCtx(ctx context.Context, hash string) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionCtx", ctx, hash)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionCtx indicates an expected call of GetTransactionCtx.
+func (mr *MockClientMockRecorder) GetTransactionCtx(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionCtx", reflect.TypeOf((*MockClient)(nil).GetTransactionCtx), ctx, hash)
+}
+
+// GetTssSigns mocks base method.
+func (m *MockClient) GetTssSigns(id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTssSigns", id, typ, extra)
+	ret0, _ := ret[0].(*pb.SignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTssSigns indicates an expected call of GetTssSigns.
+func (mr *MockClientMockRecorder) GetTssSigns(id, typ, extra interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTssSigns", reflect.TypeOf((*MockClient)(nil).GetTssSigns), id, typ, extra)
+}
+
+// GetTssSignsCtx mocks base method.
+func (m *MockClient) GetTssSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTssSignsCtx", ctx, id, typ, extra)
+	ret0, _ := ret[0].(*pb.SignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTssSignsCtx indicates an expected call of GetTssSignsCtx.
+func (mr *MockClientMockRecorder) GetTssSignsCtx(ctx, id, typ, extra interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTssSignsCtx", reflect.TypeOf((*MockClient)(nil).GetTssSignsCtx), ctx, id, typ, extra)
+}
+
+// GetValidators mocks base method.
+func (m *MockClient) GetValidators() (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidators")
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidators indicates an expected call of GetValidators.
+func (mr *MockClientMockRecorder) GetValidators() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidators", reflect.TypeOf((*MockClient)(nil).GetValidators))
+}
+
+// GetValidatorsCtx mocks base method.
+func (m *MockClient) GetValidatorsCtx(ctx context.Context) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsCtx", ctx)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsCtx indicates an expected call of GetValidatorsCtx.
+func (mr *MockClientMockRecorder) GetValidatorsCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsCtx", reflect.TypeOf((*MockClient)(nil).GetValidatorsCtx), ctx)
+}
+
+// Governance mocks base method.
+func (m *MockClient) Governance() rpcx.Governance {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Governance")
+	ret0, _ := ret[0].(rpcx.Governance)
+	return ret0
+}
+
+// Governance indicates an expected call of Governance.
+func (mr *MockClientMockRecorder) Governance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Governance", reflect.TypeOf((*MockClient)(nil).Governance))
+}
+
+// HeartBeat mocks base method.
+func (m *MockClient) HeartBeat(address, index string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartBeat", address, index)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartBeat indicates an expected call of HeartBeat.
+func (mr *MockClientMockRecorder) HeartBeat(address, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartBeat", reflect.TypeOf((*MockClient)(nil).HeartBeat), address, index)
+}
+
+// HeartBeatCtx mocks base method.
+func (m *MockClient) HeartBeatCtx(ctx context.Context, address, index string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartBeatCtx", ctx, address, index)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartBeatCtx indicates an expected call of HeartBeatCtx.
+func (mr *MockClientMockRecorder) HeartBeatCtx(ctx, address, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartBeatCtx", reflect.TypeOf((*MockClient)(nil).HeartBeatCtx), ctx, address, index)
+}
+
+// IPFSGet mocks base method.
+func (m *MockClient) IPFSGet(path string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPFSGet", path)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPFSGet indicates an expected call of IPFSGet.
 func (mr *MockClientMockRecorder) IPFSGet(path interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSGet", reflect.TypeOf((*MockClient)(nil).IPFSGet), path)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSGet", reflect.TypeOf((*MockClient)(nil).IPFSGet), path)
+}
+
+// IPFSGetToLocal mocks base method.
+func (m *MockClient) IPFSGetToLocal(path, localfPath string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPFSGetToLocal", path, localfPath)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPFSGetToLocal indicates an expected call of IPFSGetToLocal.
+func (mr *MockClientMockRecorder) IPFSGetToLocal(path, localfPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSGetToLocal", reflect.TypeOf((*MockClient)(nil).IPFSGetToLocal), path, localfPath)
+}
+
+// IPFSPutFromLocal mocks base method.
+func (m *MockClient) IPFSPutFromLocal(localfPath string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IPFSPutFromLocal", localfPath)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IPFSPutFromLocal indicates an expected call of IPFSPutFromLocal.
+func (mr *MockClientMockRecorder) IPFSPutFromLocal(localfPath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSPutFromLocal", reflect.TypeOf((*MockClient)(nil).IPFSPutFromLocal), localfPath)
+}
+
+// InvokeBVMContract mocks base method.
+func (m *MockClient) InvokeBVMContract(address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeBVMContract", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeBVMContract indicates an expected call of InvokeBVMContract.
+func (mr *MockClientMockRecorder) InvokeBVMContract(address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeBVMContract", reflect.TypeOf((*MockClient)(nil).InvokeBVMContract), varargs...)
+}
+
+// InvokeBVMContractCtx mocks base method.
+func (m *MockClient) InvokeBVMContractCtx(ctx context.Context, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeBVMContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeBVMContractCtx indicates an expected call of InvokeBVMContractCtx.
+func (mr *MockClientMockRecorder) InvokeBVMContractCtx(ctx, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeBVMContractCtx", reflect.TypeOf((*MockClient)(nil).InvokeBVMContractCtx), varargs...)
+}
+
+// InvokeContract mocks base method.
+func (m *MockClient) InvokeContract(vmType pb.TransactionData_VMType, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{vmType, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeContract", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeContract indicates an expected call of InvokeContract.
+func (mr *MockClientMockRecorder) InvokeContract(vmType, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{vmType, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeContract", reflect.TypeOf((*MockClient)(nil).InvokeContract), varargs...)
+}
+
+// InvokeContractCtx mocks base method.
+func (m *MockClient) InvokeContractCtx(ctx context.Context, vmType pb.TransactionData_VMType, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, vmType, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeContractCtx indicates an expected call of InvokeContractCtx.
+func (mr *MockClientMockRecorder) InvokeContractCtx(ctx, vmType, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, vmType, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeContractCtx", reflect.TypeOf((*MockClient)(nil).InvokeContractCtx), varargs...)
+}
+
+// InvokeXVMContract mocks base method.
+func (m *MockClient) InvokeXVMContract(address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeXVMContract", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeXVMContract indicates an expected call of InvokeXVMContract.
+func (mr *MockClientMockRecorder) InvokeXVMContract(address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeXVMContract", reflect.TypeOf((*MockClient)(nil).InvokeXVMContract), varargs...)
+}
+
+// InvokeXVMContractCtx mocks base method.
+func (m *MockClient) InvokeXVMContractCtx(ctx context.Context, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeXVMContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeXVMContractCtx indicates an expected call of InvokeXVMContractCtx.
+func (mr *MockClientMockRecorder) InvokeXVMContractCtx(ctx, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeXVMContractCtx", reflect.TypeOf((*MockClient)(nil).InvokeXVMContractCtx), varargs...)
+}
+
+// ListAppchains mocks base method.
+func (m *MockClient) ListAppchains() ([]*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppchains")
+	ret0, _ := ret[0].([]*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppchains indicates an expected call of ListAppchains.
+func (mr *MockClientMockRecorder) ListAppchains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppchains", reflect.TypeOf((*MockClient)(nil).ListAppchains))
+}
+
+// ListAppchainsCtx mocks base method.
+func (m *MockClient) ListAppchainsCtx(ctx context.Context) ([]*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppchainsCtx", ctx)
+	ret0, _ := ret[0].([]*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppchainsCtx indicates an expected call of ListAppchainsCtx.
+func (mr *MockClientMockRecorder) ListAppchainsCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppchainsCtx", reflect.TypeOf((*MockClient)(nil).ListAppchainsCtx), ctx)
+}
+
+// ListNodes mocks base method.
+func (m *MockClient) ListNodes() ([]*rpcx.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodes")
+	ret0, _ := ret[0].([]*rpcx.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodes indicates an expected call of ListNodes.
+func (mr *MockClientMockRecorder) ListNodes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodes", reflect.TypeOf((*MockClient)(nil).ListNodes))
+}
+
+// ListNodesCtx mocks base method.
+func (m *MockClient) ListNodesCtx(ctx context.Context) ([]*rpcx.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodesCtx", ctx)
+	ret0, _ := ret[0].([]*rpcx.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodesCtx indicates an expected call of ListNodesCtx.
+func (mr *MockClientMockRecorder) ListNodesCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodesCtx", reflect.TypeOf((*MockClient)(nil).ListNodesCtx), ctx)
+}
+
+// ListRules mocks base method.
+func (m *MockClient) ListRules(chainID string) ([]*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", chainID)
+	ret0, _ := ret[0].([]*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockClientMockRecorder) ListRules(chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockClient)(nil).ListRules), chainID)
+}
+
+// ListRulesCtx mocks base method.
+func (m *MockClient) ListRulesCtx(ctx context.Context, chainID string) ([]*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRulesCtx", ctx, chainID)
+	ret0, _ := ret[0].([]*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRulesCtx indicates an expected call of ListRulesCtx.
+func (mr *MockClientMockRecorder) ListRulesCtx(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRulesCtx", reflect.TypeOf((*MockClient)(nil).ListRulesCtx), ctx, chainID)
+}
+
+// ListServices mocks base method.
+func (m *MockClient) ListServices(chainID string) ([]*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServices", chainID)
+	ret0, _ := ret[0].([]*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServices indicates an expected call of ListServices.
+func (mr *MockClientMockRecorder) ListServices(chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockClient)(nil).ListServices), chainID)
+}
+
+// ListServicesCtx mocks base method.
+func (m *MockClient) ListServicesCtx(ctx context.Context, chainID string) ([]*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServicesCtx", ctx, chainID)
+	ret0, _ := ret[0].([]*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServicesCtx indicates an expected call of ListServicesCtx.
+func (mr *MockClientMockRecorder) ListServicesCtx(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesCtx", reflect.TypeOf((*MockClient)(nil).ListServicesCtx), ctx, chainID)
+}
+
+// Proposals mocks base method.
+func (m *MockClient) Proposals() rpcx.Proposals {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Proposals")
+	ret0, _ := ret[0].(rpcx.Proposals)
+	return ret0
+}
+
+// Proposals indicates an expected call of Proposals.
+func (mr *MockClientMockRecorder) Proposals() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proposals", reflect.TypeOf((*MockClient)(nil).Proposals))
+}
+
+// SendIBTP mocks base method.
+func (m *MockClient) SendIBTP(b *rpcx.IBTPBuilder, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTP", b, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTP indicates an expected call of SendIBTP.
+func (mr *MockClientMockRecorder) SendIBTP(b, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTP", reflect.TypeOf((*MockClient)(nil).SendIBTP), b, opts)
+}
+
+// SendIBTPCtx mocks base method.
+func (m *MockClient) SendIBTPCtx(ctx context.Context, b *rpcx.IBTPBuilder, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTPCtx", ctx, b, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTPCtx indicates an expected call of SendIBTPCtx.
+func (mr *MockClientMockRecorder) SendIBTPCtx(ctx, b, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTPCtx", reflect.TypeOf((*MockClient)(nil).SendIBTPCtx), ctx, b, opts)
+}
+
+// SendIBTPReceipt mocks base method.
+func (m *MockClient) SendIBTPReceipt(ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTPReceipt", ibtp, success, result, proof, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTPReceipt indicates an expected call of SendIBTPReceipt.
+func (mr *MockClientMockRecorder) SendIBTPReceipt(ibtp, success, result, proof, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTPReceipt", reflect.TypeOf((*MockClient)(nil).SendIBTPReceipt), ibtp, success, result, proof, opts)
+}
+
+// SendIBTPReceiptCtx mocks base method.
+func (m *MockClient) SendIBTPReceiptCtx(ctx context.Context, ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTPReceiptCtx", ctx, ibtp, success, result, proof, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTPReceiptCtx indicates an expected call of SendIBTPReceiptCtx.
+func (mr *MockClientMockRecorder) SendIBTPReceiptCtx(ctx, ibtp, success, result, proof, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTPReceiptCtx", reflect.TypeOf((*MockClient)(nil).SendIBTPReceiptCtx), ctx, ibtp, success, result, proof, opts)
+}
+
+// SendRawTransaction mocks base method.
+func (m *MockClient) SendRawTransaction(tx *pb.BxhTransaction) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransaction", tx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransaction indicates an expected call of SendRawTransaction.
+func (mr *MockClientMockRecorder) SendRawTransaction(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransaction", reflect.TypeOf((*MockClient)(nil).SendRawTransaction), tx)
+}
+
+// SendRawTransactionCtx mocks base method.
+func (m *MockClient) SendRawTransactionCtx(ctx context.Context, tx *pb.BxhTransaction) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransactionCtx", ctx, tx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransactionCtx indicates an expected call of SendRawTransactionCtx.
+func (mr *MockClientMockRecorder) SendRawTransactionCtx(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionCtx", reflect.TypeOf((*MockClient)(nil).SendRawTransactionCtx), ctx, tx)
+}
+
+// SendRawTransactionWithReceipt mocks base method.
+func (m *MockClient) SendRawTransactionWithReceipt(tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransactionWithReceipt", tx)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransactionWithReceipt indicates an expected call of SendRawTransactionWithReceipt.
+func (mr *MockClientMockRecorder) SendRawTransactionWithReceipt(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionWithReceipt", reflect.TypeOf((*MockClient)(nil).SendRawTransactionWithReceipt), tx)
+}
+
+// SendRawTransactionWithReceiptCtx mocks base method.
+func (m *MockClient) SendRawTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransactionWithReceiptCtx", ctx, tx)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransactionWithReceiptCtx indicates an expected call of SendRawTransactionWithReceiptCtx.
+func (mr *MockClientMockRecorder) SendRawTransactionWithReceiptCtx(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionWithReceiptCtx", reflect.TypeOf((*MockClient)(nil).SendRawTransactionWithReceiptCtx), ctx, tx)
+}
+
+// SendTransaction mocks base method.
+func (m *MockClient) SendTransaction(tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransaction", tx, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransaction indicates an expected call of SendTransaction.
+func (mr *MockClientMockRecorder) SendTransaction(tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransaction", reflect.TypeOf((*MockClient)(nil).SendTransaction), tx, opts)
+}
+
+// SendTransactionCtx mocks base method.
+func (m *MockClient) SendTransactionCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactionCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactionCtx indicates an expected call of SendTransactionCtx.
+func (mr *MockClientMockRecorder) SendTransactionCtx(ctx, tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionCtx", reflect.TypeOf((*MockClient)(nil).SendTransactionCtx), ctx, tx, opts)
+}
+
+// SendTransactionWithReceipt mocks base method.
+func (m *MockClient) SendTransactionWithReceipt(tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactionWithReceipt", tx, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactionWithReceipt indicates an expected call of SendTransactionWithReceipt.
+func (mr *MockClientMockRecorder) SendTransactionWithReceipt(tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionWithReceipt", reflect.TypeOf((*MockClient)(nil).SendTransactionWithReceipt), tx, opts)
+}
+
+// SendTransactionWithReceiptCtx mocks base method.
+func (m *MockClient) SendTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactionWithReceiptCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactionWithReceiptCtx indicates an expected call of SendTransactionWithReceiptCtx.
+func (mr *MockClientMockRecorder) SendTransactionWithReceiptCtx(ctx, tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionWithReceiptCtx", reflect.TypeOf((*MockClient)(nil).SendTransactionWithReceiptCtx), ctx, tx, opts)
+}
+
+// SendTransactions mocks base method.
+func (m *MockClient) SendTransactions(txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactions", txs)
+	ret0, _ := ret[0].(*pb.MultiTransactionHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactions indicates an expected call of SendTransactions.
+func (mr *MockClientMockRecorder) SendTransactions(txs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactions", reflect.TypeOf((*MockClient)(nil).SendTransactions), txs)
+}
+
+// SendTransactionsCtx mocks base method.
+func (m *MockClient) SendTransactionsCtx(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactionsCtx", ctx, txs)
+	ret0, _ := ret[0].(*pb.MultiTransactionHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactionsCtx indicates an expected call of SendTransactionsCtx.
+func (mr *MockClientMockRecorder) SendTransactionsCtx(ctx, txs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionsCtx", reflect.TypeOf((*MockClient)(nil).SendTransactionsCtx), ctx, txs)
+}
+
+// SendView mocks base method.
+func (m *MockClient) SendView(tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendView", tx)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendView indicates an expected call of SendView.
+func (mr *MockClientMockRecorder) SendView(tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendView", reflect.TypeOf((*MockClient)(nil).SendView), tx)
+}
+
+// SendViewCtx mocks base method.
+func (m *MockClient) SendViewCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendViewCtx", ctx, tx)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendViewCtx indicates an expected call of SendViewCtx.
+func (mr *MockClientMockRecorder) SendViewCtx(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendViewCtx", reflect.TypeOf((*MockClient)(nil).SendViewCtx), ctx, tx)
+}
+
+// SetMasterPier mocks base method.
+func (m *MockClient) SetMasterPier(address, index string, timeout int64) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMasterPier", address, index, timeout)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMasterPier indicates an expected call of SetMasterPier.
+func (mr *MockClientMockRecorder) SetMasterPier(address, index, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterPier", reflect.TypeOf((*MockClient)(nil).SetMasterPier), address, index, timeout)
+}
+
+// SetMasterPierCtx mocks base method.
+func (m *MockClient) SetMasterPierCtx(ctx context.Context, address, index string, timeout int64) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMasterPierCtx", ctx, address, index, timeout)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMasterPierCtx indicates an expected call of SetMasterPierCtx.
+func (mr *MockClientMockRecorder) SetMasterPierCtx(ctx, address, index, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterPierCtx", reflect.TypeOf((*MockClient)(nil).SetMasterPierCtx), ctx, address, index, timeout)
+}
+
+// SetPrivateKey mocks base method.
+func (m *MockClient) SetPrivateKey(arg0 crypto.PrivateKey) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPrivateKey", arg0)
+}
+
+// SetPrivateKey indicates an expected call of SetPrivateKey.
+func (mr *MockClientMockRecorder) SetPrivateKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivateKey", reflect.TypeOf((*MockClient)(nil).SetPrivateKey), arg0)
+}
+
+// SetSigner mocks base method.
+func (m *MockClient) SetSigner(arg0 rpcx.Signer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSigner", arg0)
+}
+
+// SetSigner indicates an expected call of SetSigner.
+func (mr *MockClientMockRecorder) SetSigner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSigner", reflect.TypeOf((*MockClient)(nil).SetSigner), arg0)
+}
+
+// Stop mocks base method.
+func (m *MockClient) Stop() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockClientMockRecorder) Stop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
+}
+
+// SubmitTxEnvelope mocks base method.
+func (m *MockClient) SubmitTxEnvelope(env *rpcx.TxEnvelope) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTxEnvelope", env)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTxEnvelope indicates an expected call of SubmitTxEnvelope.
+func (mr *MockClientMockRecorder) SubmitTxEnvelope(env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTxEnvelope", reflect.TypeOf((*MockClient)(nil).SubmitTxEnvelope), env)
+}
+
+// SubmitTxEnvelopeCtx mocks base method.
+func (m *MockClient) SubmitTxEnvelopeCtx(ctx context.Context, env *rpcx.TxEnvelope) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTxEnvelopeCtx", ctx, env)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTxEnvelopeCtx indicates an expected call of SubmitTxEnvelopeCtx.
+func (mr *MockClientMockRecorder) SubmitTxEnvelopeCtx(ctx, env interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTxEnvelopeCtx", reflect.TypeOf((*MockClient)(nil).SubmitTxEnvelopeCtx), ctx, env)
+}
+
+// Subscribe mocks base method.
+func (m *MockClient) Subscribe(arg0 context.Context, arg1 pb.SubscriptionRequest_Type, arg2 []byte) (<-chan interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockClientMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockClient)(nil).Subscribe), arg0, arg1, arg2)
+}
+
+// SubscribeAudit mocks base method.
+func (m *MockClient) SubscribeAudit(arg0 context.Context, arg1 pb.AuditSubscriptionRequest_Type, arg2 uint64, arg3 []byte) (<-chan interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeAudit", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(<-chan interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeAudit indicates an expected call of SubscribeAudit.
+func (mr *MockClientMockRecorder) SubscribeAudit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAudit", reflect.TypeOf((*MockClient)(nil).SubscribeAudit), arg0, arg1, arg2, arg3)
+}
+
+// SubscribeAuditTxInfo mocks base method.
+func (m *MockClient) SubscribeAuditTxInfo(ctx context.Context, blockHeight uint64) (<-chan *pb.AuditTxInfo, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeAuditTxInfo", ctx, blockHeight)
+	ret0, _ := ret[0].(<-chan *pb.AuditTxInfo)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeAuditTxInfo indicates an expected call of SubscribeAuditTxInfo.
+func (mr *MockClientMockRecorder) SubscribeAuditTxInfo(ctx, blockHeight interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAuditTxInfo", reflect.TypeOf((*MockClient)(nil).SubscribeAuditTxInfo), ctx, blockHeight)
+}
+
+// SubscribeBlockHeaders mocks base method.
+func (m *MockClient) SubscribeBlockHeaders(ctx context.Context) (<-chan *pb.BlockHeader, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBlockHeaders", ctx)
+	ret0, _ := ret[0].(<-chan *pb.BlockHeader)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeBlockHeaders indicates an expected call of SubscribeBlockHeaders.
+func (mr *MockClientMockRecorder) SubscribeBlockHeaders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlockHeaders", reflect.TypeOf((*MockClient)(nil).SubscribeBlockHeaders), ctx)
+}
+
+// SubscribeBlockHeadersFrom mocks base method.
+func (m *MockClient) SubscribeBlockHeadersFrom(ctx context.Context, height uint64) <-chan *pb.BlockHeader {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBlockHeadersFrom", ctx, height)
+	ret0, _ := ret[0].(<-chan *pb.BlockHeader)
+	return ret0
+}
+
+// SubscribeBlockHeadersFrom indicates an expected call of SubscribeBlockHeadersFrom.
+func (mr *MockClientMockRecorder) SubscribeBlockHeadersFrom(ctx, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlockHeadersFrom", reflect.TypeOf((*MockClient)(nil).SubscribeBlockHeadersFrom), ctx, height)
+}
+
+// SubscribeBlocks mocks base method.
+func (m *MockClient) SubscribeBlocks(ctx context.Context) (<-chan *pb.Block, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBlocks", ctx)
+	ret0, _ := ret[0].(<-chan *pb.Block)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeBlocks indicates an expected call of SubscribeBlocks.
+func (mr *MockClientMockRecorder) SubscribeBlocks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlocks", reflect.TypeOf((*MockClient)(nil).SubscribeBlocks), ctx)
+}
+
+// SubscribeBlocksFrom mocks base method.
+func (m *MockClient) SubscribeBlocksFrom(ctx context.Context, height uint64) <-chan *pb.Block {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeBlocksFrom", ctx, height)
+	ret0, _ := ret[0].(<-chan *pb.Block)
+	return ret0
+}
+
+// SubscribeBlocksFrom indicates an expected call of SubscribeBlocksFrom.
+func (mr *MockClientMockRecorder) SubscribeBlocksFrom(ctx, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeBlocksFrom", reflect.TypeOf((*MockClient)(nil).SubscribeBlocksFrom), ctx, height)
+}
+
+// SubscribeEvents mocks base method.
+func (m *MockClient) SubscribeEvents(ctx context.Context) (<-chan *pb.Event, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeEvents", ctx)
+	ret0, _ := ret[0].(<-chan *pb.Event)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeEvents indicates an expected call of SubscribeEvents.
+func (mr *MockClientMockRecorder) SubscribeEvents(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeEvents", reflect.TypeOf((*MockClient)(nil).SubscribeEvents), ctx)
+}
+
+// SubscribeInterchainTx mocks base method.
+func (m *MockClient) SubscribeInterchainTx(ctx context.Context) (<-chan *pb.IBTP, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeInterchainTx", ctx)
+	ret0, _ := ret[0].(<-chan *pb.IBTP)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeInterchainTx indicates an expected call of SubscribeInterchainTx.
+func (mr *MockClientMockRecorder) SubscribeInterchainTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeInterchainTx", reflect.TypeOf((*MockClient)(nil).SubscribeInterchainTx), ctx)
+}
+
+// SubscribeInterchainTxWrappers mocks base method.
+func (m *MockClient) SubscribeInterchainTxWrappers(ctx context.Context, pid string) (<-chan *pb.InterchainTxWrappers, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeInterchainTxWrappers", ctx, pid)
+	ret0, _ := ret[0].(<-chan *pb.InterchainTxWrappers)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SubscribeInterchainTxWrappers indicates an expected call of SubscribeInterchainTxWrappers.
+func (mr *MockClientMockRecorder) SubscribeInterchainTxWrappers(ctx, pid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeInterchainTxWrappers", reflect.TypeOf((*MockClient)(nil).SubscribeInterchainTxWrappers), ctx, pid)
+}
+
+// SyncInterchainTxWrappers mocks base method.
+func (m *MockClient) SyncInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64) (<-chan *pb.InterchainTxWrappers, <-chan error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncInterchainTxWrappers", ctx, pid, begin, end)
+	ret0, _ := ret[0].(<-chan *pb.InterchainTxWrappers)
+	ret1, _ := ret[1].(<-chan error)
+	return ret0, ret1
+}
+
+// SyncInterchainTxWrappers indicates an expected call of SyncInterchainTxWrappers.
+func (mr *MockClientMockRecorder) SyncInterchainTxWrappers(ctx, pid, begin, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncInterchainTxWrappers", reflect.TypeOf((*MockClient)(nil).SyncInterchainTxWrappers), ctx, pid, begin, end)
+}
+
+// VerifyInterchainTxWrappers mocks base method.
+func (m *MockClient) VerifyInterchainTxWrappers(wrappers *pb.InterchainTxWrappers) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyInterchainTxWrappers", wrappers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyInterchainTxWrappers indicates an expected call of VerifyInterchainTxWrappers.
+func (mr *MockClientMockRecorder) VerifyInterchainTxWrappers(wrappers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyInterchainTxWrappers", reflect.TypeOf((*MockClient)(nil).VerifyInterchainTxWrappers), wrappers)
+}
+
+// VerifyInterchainTxWrappersCtx mocks base method.
+func (m *MockClient) VerifyInterchainTxWrappersCtx(ctx context.Context, wrappers *pb.InterchainTxWrappers) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyInterchainTxWrappersCtx", ctx, wrappers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyInterchainTxWrappersCtx indicates an expected call of VerifyInterchainTxWrappersCtx.
+func (mr *MockClientMockRecorder) VerifyInterchainTxWrappersCtx(ctx, wrappers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyInterchainTxWrappersCtx", reflect.TypeOf((*MockClient)(nil).VerifyInterchainTxWrappersCtx), ctx, wrappers)
+}
+
+// VerifyMultiSigns mocks base method.
+func (m *MockClient) VerifyMultiSigns(content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*rpcx.MultiSignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMultiSigns", content, typ, signs)
+	ret0, _ := ret[0].(*rpcx.MultiSignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMultiSigns indicates an expected call of VerifyMultiSigns.
+func (mr *MockClientMockRecorder) VerifyMultiSigns(content, typ, signs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMultiSigns", reflect.TypeOf((*MockClient)(nil).VerifyMultiSigns), content, typ, signs)
+}
+
+// VerifyMultiSignsCtx mocks base method.
+func (m *MockClient) VerifyMultiSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*rpcx.MultiSignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMultiSignsCtx", ctx, content, typ, signs)
+	ret0, _ := ret[0].(*rpcx.MultiSignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMultiSignsCtx indicates an expected call of VerifyMultiSignsCtx.
+func (mr *MockClientMockRecorder) VerifyMultiSignsCtx(ctx, content, typ, signs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMultiSignsCtx", reflect.TypeOf((*MockClient)(nil).VerifyMultiSignsCtx), ctx, content, typ, signs)
+}
+
+// WaitForReceipt mocks base method.
+func (m *MockClient) WaitForReceipt(hash string) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForReceipt", hash)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForReceipt indicates an expected call of WaitForReceipt.
+func (mr *MockClientMockRecorder) WaitForReceipt(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReceipt", reflect.TypeOf((*MockClient)(nil).WaitForReceipt), hash)
+}
+
+// WaitForReceiptCtx mocks base method.
+func (m *MockClient) WaitForReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForReceiptCtx", ctx, hash)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForReceiptCtx indicates an expected call of WaitForReceiptCtx.
+func (mr *MockClientMockRecorder) WaitForReceiptCtx(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReceiptCtx", reflect.TypeOf((*MockClient)(nil).WaitForReceiptCtx), ctx, hash)
+}
+
+// WaitIBTP mocks base method.
+func (m *MockClient) WaitIBTP(id string) (pb.TransactionStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitIBTP", id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitIBTP indicates an expected call of WaitIBTP.
+func (mr *MockClientMockRecorder) WaitIBTP(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitIBTP", reflect.TypeOf((*MockClient)(nil).WaitIBTP), id)
+}
+
+// WaitIBTPCtx mocks base method.
+func (m *MockClient) WaitIBTPCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitIBTPCtx", ctx, id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitIBTPCtx indicates an expected call of WaitIBTPCtx.
+func (mr *MockClientMockRecorder) WaitIBTPCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitIBTPCtx", reflect.TypeOf((*MockClient)(nil).WaitIBTPCtx), ctx, id)
+}
+
+// MockContextClient is a mock of ContextClient interface.
+type MockContextClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextClientMockRecorder
+}
+
+// MockContextClientMockRecorder is the mock recorder for MockContextClient.
+type MockContextClientMockRecorder struct {
+	mock *MockContextClient
+}
+
+// NewMockContextClient creates a new mock instance.
+func NewMockContextClient(ctrl *gomock.Controller) *MockContextClient {
+	mock := &MockContextClient{ctrl: ctrl}
+	mock.recorder = &MockContextClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextClient) EXPECT() *MockContextClientMockRecorder {
+	return m.recorder
+}
+
+// CheckMasterPierCtx mocks base method.
+func (m *MockContextClient) CheckMasterPierCtx(ctx context.Context, address string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMasterPierCtx", ctx, address)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckMasterPierCtx indicates an expected call of CheckMasterPierCtx.
+func (mr *MockContextClientMockRecorder) CheckMasterPierCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMasterPierCtx", reflect.TypeOf((*MockContextClient)(nil).CheckMasterPierCtx), ctx, address)
+}
+
+// DeployContractCtx mocks base method.
+func (m *MockContextClient) DeployContractCtx(ctx context.Context, contract []byte, opts *rpcx.TransactOpts) (*types.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployContractCtx", ctx, contract, opts)
+	ret0, _ := ret[0].(*types.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeployContractCtx indicates an expected call of DeployContractCtx.
+func (mr *MockContextClientMockRecorder) DeployContractCtx(ctx, contract, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployContractCtx", reflect.TypeOf((*MockContextClient)(nil).DeployContractCtx), ctx, contract, opts)
+}
+
+// ExportUnsignedTxCtx mocks base method.
+func (m *MockContextClient) ExportUnsignedTxCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*rpcx.TxEnvelope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUnsignedTxCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(*rpcx.TxEnvelope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUnsignedTxCtx indicates an expected call of ExportUnsignedTxCtx.
+func (mr *MockContextClientMockRecorder) ExportUnsignedTxCtx(ctx, tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUnsignedTxCtx", reflect.TypeOf((*MockContextClient)(nil).ExportUnsignedTxCtx), ctx, tx, opts)
+}
+
+// GetAccountBalanceCtx mocks base method.
+func (m *MockContextClient) GetAccountBalanceCtx(ctx context.Context, address string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalanceCtx", ctx, address)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalanceCtx indicates an expected call of GetAccountBalanceCtx.
+func (mr *MockContextClientMockRecorder) GetAccountBalanceCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalanceCtx", reflect.TypeOf((*MockContextClient)(nil).GetAccountBalanceCtx), ctx, address)
+}
+
+// GetAccountCtx mocks base method.
+func (m *MockContextClient) GetAccountCtx(ctx context.Context, address string) (*rpcx.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountCtx", ctx, address)
+	ret0, _ := ret[0].(*rpcx.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountCtx indicates an expected call of GetAccountCtx.
+func (mr *MockContextClientMockRecorder) GetAccountCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountCtx", reflect.TypeOf((*MockContextClient)(nil).GetAccountCtx), ctx, address)
+}
+
+// GetAppchainCtx mocks base method.
+func (m *MockContextClient) GetAppchainCtx(ctx context.Context, id string) (*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppchainCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppchainCtx indicates an expected call of GetAppchainCtx.
+func (mr *MockContextClientMockRecorder) GetAppchainCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppchainCtx", reflect.TypeOf((*MockContextClient)(nil).GetAppchainCtx), ctx, id)
+}
+
+// GetBalanceCtx mocks base method.
+func (m *MockContextClient) GetBalanceCtx(ctx context.Context, address string) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceCtx", ctx, address)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceCtx indicates an expected call of GetBalanceCtx.
+func (mr *MockContextClientMockRecorder) GetBalanceCtx(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceCtx", reflect.TypeOf((*MockContextClient)(nil).GetBalanceCtx), ctx, address)
+}
+
+// GetBalancesCtx mocks base method.
+func (m *MockContextClient) GetBalancesCtx(ctx context.Context, addresses []string) (map[string]*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalancesCtx", ctx, addresses)
+	ret0, _ := ret[0].(map[string]*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalancesCtx indicates an expected call of GetBalancesCtx.
+func (mr *MockContextClientMockRecorder) GetBalancesCtx(ctx, addresses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalancesCtx", reflect.TypeOf((*MockContextClient)(nil).GetBalancesCtx), ctx, addresses)
+}
+
+// GetBlockCtx mocks base method.
+func (m *MockContextClient) GetBlockCtx(ctx context.Context, value string, blockType pb.GetBlockRequest_Type, fullTx bool) (*pb.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCtx", ctx, value, blockType, fullTx)
+	ret0, _ := ret[0].(*pb.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCtx indicates an expected call of GetBlockCtx.
+func (mr *MockContextClientMockRecorder) GetBlockCtx(ctx, value, blockType, fullTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCtx", reflect.TypeOf((*MockContextClient)(nil).GetBlockCtx), ctx, value, blockType, fullTx)
+}
+
+// GetBlocksCtx mocks base method.
+func (m *MockContextClient) GetBlocksCtx(ctx context.Context, start, end uint64, fullTx bool) (*pb.GetBlocksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksCtx", ctx, start, end, fullTx)
+	ret0, _ := ret[0].(*pb.GetBlocksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksCtx indicates an expected call of GetBlocksCtx.
+func (mr *MockContextClientMockRecorder) GetBlocksCtx(ctx, start, end, fullTx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksCtx", reflect.TypeOf((*MockContextClient)(nil).GetBlocksCtx), ctx, start, end, fullTx)
+}
+
+// GetChainIDCtx mocks base method.
+func (m *MockContextClient) GetChainIDCtx(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainIDCtx", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainIDCtx indicates an expected call of GetChainIDCtx.
+func (mr *MockContextClientMockRecorder) GetChainIDCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainIDCtx", reflect.TypeOf((*MockContextClient)(nil).GetChainIDCtx), ctx)
+}
+
+// GetChainMetaCtx mocks base method.
+func (m *MockContextClient) GetChainMetaCtx(ctx context.Context) (*pb.ChainMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainMetaCtx", ctx)
+	ret0, _ := ret[0].(*pb.ChainMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainMetaCtx indicates an expected call of GetChainMetaCtx.
+func (mr *MockContextClientMockRecorder) GetChainMetaCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainMetaCtx", reflect.TypeOf((*MockContextClient)(nil).GetChainMetaCtx), ctx)
+}
+
+// GetChainStatusCtx mocks base method.
+func (m *MockContextClient) GetChainStatusCtx(ctx context.Context) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainStatusCtx", ctx)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainStatusCtx indicates an expected call of GetChainStatusCtx.
+func (mr *MockContextClientMockRecorder) GetChainStatusCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainStatusCtx", reflect.TypeOf((*MockContextClient)(nil).GetChainStatusCtx), ctx)
+}
+
+// GetIBTPStatusCtx mocks base method.
+func (m *MockContextClient) GetIBTPStatusCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIBTPStatusCtx", ctx, id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIBTPStatusCtx indicates an expected call of GetIBTPStatusCtx.
+func (mr *MockContextClientMockRecorder) GetIBTPStatusCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIBTPStatusCtx", reflect.TypeOf((*MockContextClient)(nil).GetIBTPStatusCtx), ctx, id)
+}
+
+// GetInterchainCtx mocks base method.
+func (m *MockContextClient) GetInterchainCtx(ctx context.Context, id string) (*rpcx.Interchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterchainCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Interchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterchainCtx indicates an expected call of GetInterchainCtx.
+func (mr *MockContextClientMockRecorder) GetInterchainCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainCtx", reflect.TypeOf((*MockContextClient)(nil).GetInterchainCtx), ctx, id)
+}
+
+// GetInterchainMetaCtx mocks base method.
+func (m *MockContextClient) GetInterchainMetaCtx(ctx context.Context, serviceID string) (*rpcx.Interchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterchainMetaCtx", ctx, serviceID)
+	ret0, _ := ret[0].(*rpcx.Interchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterchainMetaCtx indicates an expected call of GetInterchainMetaCtx.
+func (mr *MockContextClientMockRecorder) GetInterchainMetaCtx(ctx, serviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterchainMetaCtx", reflect.TypeOf((*MockContextClient)(nil).GetInterchainMetaCtx), ctx, serviceID)
+}
+
+// GetMultiSignsCtx mocks base method.
+func (m *MockContextClient) GetMultiSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiSignsCtx", ctx, id, typ)
+	ret0, _ := ret[0].(*pb.SignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiSignsCtx indicates an expected call of GetMultiSignsCtx.
+func (mr *MockContextClientMockRecorder) GetMultiSignsCtx(ctx, id, typ interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiSignsCtx", reflect.TypeOf((*MockContextClient)(nil).GetMultiSignsCtx), ctx, id, typ)
+}
+
+// GetNetworkMetaCtx mocks base method.
+func (m *MockContextClient) GetNetworkMetaCtx(ctx context.Context) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkMetaCtx", ctx)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkMetaCtx indicates an expected call of GetNetworkMetaCtx.
+func (mr *MockContextClientMockRecorder) GetNetworkMetaCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkMetaCtx", reflect.TypeOf((*MockContextClient)(nil).GetNetworkMetaCtx), ctx)
+}
+
+// GetNodeCtx mocks base method.
+func (m *MockContextClient) GetNodeCtx(ctx context.Context, account string) (*rpcx.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeCtx", ctx, account)
+	ret0, _ := ret[0].(*rpcx.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNodeCtx indicates an expected call of GetNodeCtx.
+func (mr *MockContextClientMockRecorder) GetNodeCtx(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeCtx", reflect.TypeOf((*MockContextClient)(nil).GetNodeCtx), ctx, account)
+}
+
+// GetPendingNonceByAccountCtx mocks base method.
+func (m *MockContextClient) GetPendingNonceByAccountCtx(ctx context.Context, account string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingNonceByAccountCtx", ctx, account)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingNonceByAccountCtx indicates an expected call of GetPendingNonceByAccountCtx.
+func (mr *MockContextClientMockRecorder) GetPendingNonceByAccountCtx(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingNonceByAccountCtx", reflect.TypeOf((*MockContextClient)(nil).GetPendingNonceByAccountCtx), ctx, account)
+}
+
+// GetReceiptCtx mocks base method.
+func (m *MockContextClient) GetReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceiptCtx", ctx, hash)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceiptCtx indicates an expected call of GetReceiptCtx.
+func (mr *MockContextClientMockRecorder) GetReceiptCtx(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceiptCtx", reflect.TypeOf((*MockContextClient)(nil).GetReceiptCtx), ctx, hash)
+}
+
+// GetRoleCtx mocks base method.
+func (m *MockContextClient) GetRoleCtx(ctx context.Context, id string) (*rpcx.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleCtx", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleCtx indicates an expected call of GetRoleCtx.
+func (mr *MockContextClientMockRecorder) GetRoleCtx(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleCtx", reflect.TypeOf((*MockContextClient)(nil).GetRoleCtx), ctx, id)
+}
+
+// GetRuleCtx mocks base method.
+func (m *MockContextClient) GetRuleCtx(ctx context.Context, chainID, ruleAddr string) (*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleCtx", ctx, chainID, ruleAddr)
+	ret0, _ := ret[0].(*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuleCtx indicates an expected call of GetRuleCtx.
+func (mr *MockContextClientMockRecorder) GetRuleCtx(ctx, chainID, ruleAddr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleCtx", reflect.TypeOf((*MockContextClient)(nil).GetRuleCtx), ctx, chainID, ruleAddr)
+}
+
+// GetServiceCtx mocks base method.
+func (m *MockContextClient) GetServiceCtx(ctx context.Context, chainServiceID string) (*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceCtx", ctx, chainServiceID)
+	ret0, _ := ret[0].(*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceCtx indicates an expected call of GetServiceCtx.
+func (mr *MockContextClientMockRecorder) GetServiceCtx(ctx, chainServiceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCtx", reflect.TypeOf((*MockContextClient)(nil).GetServiceCtx), ctx, chainServiceID)
+}
+
+// GetTPSCtx mocks base method.
+func (m *MockContextClient) GetTPSCtx(ctx context.Context, begin, end uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTPSCtx", ctx, begin, end)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTPSCtx indicates an expected call of GetTPSCtx.
+func (mr *MockContextClientMockRecorder) GetTPSCtx(ctx, begin, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTPSCtx", reflect.TypeOf((*MockContextClient)(nil).GetTPSCtx), ctx, begin, end)
+}
+
+// GetTransactionByBlockHashAndIndexCtx mocks base method.
+func (m *MockContextClient) GetTransactionByBlockHashAndIndexCtx(ctx context.Context, blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockHashAndIndexCtx", ctx, blockHash, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockHashAndIndexCtx indicates an expected call of GetTransactionByBlockHashAndIndexCtx.
+func (mr *MockContextClientMockRecorder) GetTransactionByBlockHashAndIndexCtx(ctx, blockHash, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockHashAndIndexCtx", reflect.TypeOf((*MockContextClient)(nil).GetTransactionByBlockHashAndIndexCtx), ctx, blockHash, index)
+}
+
+// GetTransactionByBlockNumberAndIndexCtx mocks base method.
+func (m *MockContextClient) GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNum, index uint64) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByBlockNumberAndIndexCtx", ctx, blockNum, index)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByBlockNumberAndIndexCtx indicates an expected call of GetTransactionByBlockNumberAndIndexCtx.
+func (mr *MockContextClientMockRecorder) GetTransactionByBlockNumberAndIndexCtx(ctx, blockNum, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByBlockNumberAndIndexCtx", reflect.TypeOf((*MockContextClient)(nil).GetTransactionByBlockNumberAndIndexCtx), ctx, blockNum, index)
+}
+
+// GetTransactionCtx mocks base method.
+func (m *MockContextClient) GetTransactionCtx(ctx context.Context, hash string) (*pb.GetTransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionCtx", ctx, hash)
+	ret0, _ := ret[0].(*pb.GetTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionCtx indicates an expected call of GetTransactionCtx.
+func (mr *MockContextClientMockRecorder) GetTransactionCtx(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionCtx", reflect.TypeOf((*MockContextClient)(nil).GetTransactionCtx), ctx, hash)
+}
+
+// GetTssSignsCtx mocks base method.
+func (m *MockContextClient) GetTssSignsCtx(ctx context.Context, id string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTssSignsCtx", ctx, id, typ, extra)
+	ret0, _ := ret[0].(*pb.SignResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTssSignsCtx indicates an expected call of GetTssSignsCtx.
+func (mr *MockContextClientMockRecorder) GetTssSignsCtx(ctx, id, typ, extra interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTssSignsCtx", reflect.TypeOf((*MockContextClient)(nil).GetTssSignsCtx), ctx, id, typ, extra)
+}
+
+// GetValidatorsCtx mocks base method.
+func (m *MockContextClient) GetValidatorsCtx(ctx context.Context) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsCtx", ctx)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsCtx indicates an expected call of GetValidatorsCtx.
+func (mr *MockContextClientMockRecorder) GetValidatorsCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsCtx", reflect.TypeOf((*MockContextClient)(nil).GetValidatorsCtx), ctx)
+}
+
+// HeartBeatCtx mocks base method.
+func (m *MockContextClient) HeartBeatCtx(ctx context.Context, address, index string) (*pb.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartBeatCtx", ctx, address, index)
+	ret0, _ := ret[0].(*pb.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartBeatCtx indicates an expected call of HeartBeatCtx.
+func (mr *MockContextClientMockRecorder) HeartBeatCtx(ctx, address, index interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartBeatCtx", reflect.TypeOf((*MockContextClient)(nil).HeartBeatCtx), ctx, address, index)
+}
+
+// InvokeBVMContractCtx mocks base method.
+func (m *MockContextClient) InvokeBVMContractCtx(ctx context.Context, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeBVMContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeBVMContractCtx indicates an expected call of InvokeBVMContractCtx.
+func (mr *MockContextClientMockRecorder) InvokeBVMContractCtx(ctx, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeBVMContractCtx", reflect.TypeOf((*MockContextClient)(nil).InvokeBVMContractCtx), varargs...)
+}
+
+// InvokeContractCtx mocks base method.
+func (m *MockContextClient) InvokeContractCtx(ctx context.Context, vmType pb.TransactionData_VMType, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, vmType, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeContractCtx indicates an expected call of InvokeContractCtx.
+func (mr *MockContextClientMockRecorder) InvokeContractCtx(ctx, vmType, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, vmType, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeContractCtx", reflect.TypeOf((*MockContextClient)(nil).InvokeContractCtx), varargs...)
+}
+
+// InvokeXVMContractCtx mocks base method.
+func (m *MockContextClient) InvokeXVMContractCtx(ctx context.Context, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, address, method, opts}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvokeXVMContractCtx", varargs...)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InvokeXVMContractCtx indicates an expected call of InvokeXVMContractCtx.
+func (mr *MockContextClientMockRecorder) InvokeXVMContractCtx(ctx, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, address, method, opts}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeXVMContractCtx", reflect.TypeOf((*MockContextClient)(nil).InvokeXVMContractCtx), varargs...)
+}
+
+// ListAppchainsCtx mocks base method.
+func (m *MockContextClient) ListAppchainsCtx(ctx context.Context) ([]*rpcx.Appchain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppchainsCtx", ctx)
+	ret0, _ := ret[0].([]*rpcx.Appchain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppchainsCtx indicates an expected call of ListAppchainsCtx.
+func (mr *MockContextClientMockRecorder) ListAppchainsCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppchainsCtx", reflect.TypeOf((*MockContextClient)(nil).ListAppchainsCtx), ctx)
+}
+
+// ListNodesCtx mocks base method.
+func (m *MockContextClient) ListNodesCtx(ctx context.Context) ([]*rpcx.Node, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodesCtx", ctx)
+	ret0, _ := ret[0].([]*rpcx.Node)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodesCtx indicates an expected call of ListNodesCtx.
+func (mr *MockContextClientMockRecorder) ListNodesCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodesCtx", reflect.TypeOf((*MockContextClient)(nil).ListNodesCtx), ctx)
+}
+
+// ListRulesCtx mocks base method.
+func (m *MockContextClient) ListRulesCtx(ctx context.Context, chainID string) ([]*rpcx.Rule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRulesCtx", ctx, chainID)
+	ret0, _ := ret[0].([]*rpcx.Rule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRulesCtx indicates an expected call of ListRulesCtx.
+func (mr *MockContextClientMockRecorder) ListRulesCtx(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRulesCtx", reflect.TypeOf((*MockContextClient)(nil).ListRulesCtx), ctx, chainID)
+}
+
+// ListServicesCtx mocks base method.
+func (m *MockContextClient) ListServicesCtx(ctx context.Context, chainID string) ([]*rpcx.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServicesCtx", ctx, chainID)
+	ret0, _ := ret[0].([]*rpcx.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServicesCtx indicates an expected call of ListServicesCtx.
+func (mr *MockContextClientMockRecorder) ListServicesCtx(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesCtx", reflect.TypeOf((*MockContextClient)(nil).ListServicesCtx), ctx, chainID)
+}
+
+// SendIBTPCtx mocks base method.
+func (m *MockContextClient) SendIBTPCtx(ctx context.Context, b *rpcx.IBTPBuilder, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTPCtx", ctx, b, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTPCtx indicates an expected call of SendIBTPCtx.
+func (mr *MockContextClientMockRecorder) SendIBTPCtx(ctx, b, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTPCtx", reflect.TypeOf((*MockContextClient)(nil).SendIBTPCtx), ctx, b, opts)
+}
+
+// SendIBTPReceiptCtx mocks base method.
+func (m *MockContextClient) SendIBTPReceiptCtx(ctx context.Context, ibtp *pb.IBTP, success bool, result *pb.Result, proof []byte, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendIBTPReceiptCtx", ctx, ibtp, success, result, proof, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendIBTPReceiptCtx indicates an expected call of SendIBTPReceiptCtx.
+func (mr *MockContextClientMockRecorder) SendIBTPReceiptCtx(ctx, ibtp, success, result, proof, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendIBTPReceiptCtx", reflect.TypeOf((*MockContextClient)(nil).SendIBTPReceiptCtx), ctx, ibtp, success, result, proof, opts)
+}
+
+// SendRawTransactionCtx mocks base method.
+func (m *MockContextClient) SendRawTransactionCtx(ctx context.Context, tx *pb.BxhTransaction) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransactionCtx", ctx, tx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransactionCtx indicates an expected call of SendRawTransactionCtx.
+func (mr *MockContextClientMockRecorder) SendRawTransactionCtx(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionCtx", reflect.TypeOf((*MockContextClient)(nil).SendRawTransactionCtx), ctx, tx)
+}
+
+// SendRawTransactionWithReceiptCtx mocks base method.
+func (m *MockContextClient) SendRawTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRawTransactionWithReceiptCtx", ctx, tx)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRawTransactionWithReceiptCtx indicates an expected call of SendRawTransactionWithReceiptCtx.
+func (mr *MockContextClientMockRecorder) SendRawTransactionWithReceiptCtx(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionWithReceiptCtx", reflect.TypeOf((*MockContextClient)(nil).SendRawTransactionWithReceiptCtx), ctx, tx)
+}
+
+// SendTransactionCtx mocks base method.
+func (m *MockContextClient) SendTransactionCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTransactionCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendTransactionCtx indicates an expected call of SendTransactionCtx.
+func (mr *MockContextClientMockRecorder) SendTransactionCtx(ctx, tx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionCtx", reflect.TypeOf((*MockContextClient)(nil).SendTransactionCtx), ctx, tx, opts)
 }
 
-// IPFSGetToLocal mocks base method.
-func (m *MockClient) IPFSGetToLocal(path, localfPath string) (*pb.Response, error) {
+// SendTransactionWithReceiptCtx mocks base method.
+func (m *MockContextClient) SendTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IPFSGetToLocal", path, localfPath)
-	ret0, _ := ret[0].(*pb.Response)
+	ret := m.ctrl.Call(m, "SendTransactionWithReceiptCtx", ctx, tx, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IPFSGetToLocal indicates an expected call of IPFSGetToLocal.
-func (mr *MockClientMockRecorder) IPFSGetToLocal(path, localfPath interface{}) *gomock.Call {
+// SendTransactionWithReceiptCtx indicates an expected call of SendTransactionWithReceiptCtx.
+func (mr *MockContextClientMockRecorder) SendTransactionWithReceiptCtx(ctx, tx, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSGetToLocal", reflect.TypeOf((*MockClient)(nil).IPFSGetToLocal), path, localfPath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionWithReceiptCtx", reflect.TypeOf((*MockContextClient)(nil).SendTransactionWithReceiptCtx), ctx, tx, opts)
 }
 
-// IPFSPutFromLocal mocks base method.
-func (m *MockClient) IPFSPutFromLocal(localfPath string) (*pb.Response, error) {
+// SendTransactionsCtx mocks base method.
+func (m *MockContextClient) SendTransactionsCtx(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IPFSPutFromLocal", localfPath)
-	ret0, _ := ret[0].(*pb.Response)
+	ret := m.ctrl.Call(m, "SendTransactionsCtx", ctx, txs)
+	ret0, _ := ret[0].(*pb.MultiTransactionHash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IPFSPutFromLocal indicates an expected call of IPFSPutFromLocal.
-func (mr *MockClientMockRecorder) IPFSPutFromLocal(localfPath interface{}) *gomock.Call {
+// SendTransactionsCtx indicates an expected call of SendTransactionsCtx.
+func (mr *MockContextClientMockRecorder) SendTransactionsCtx(ctx, txs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IPFSPutFromLocal", reflect.TypeOf((*MockClient)(nil).IPFSPutFromLocal), localfPath)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionsCtx", reflect.TypeOf((*MockContextClient)(nil).SendTransactionsCtx), ctx, txs)
 }
 
-// InvokeBVMContract mocks base method.
-func (m *MockClient) InvokeBVMContract(address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+// SendViewCtx mocks base method.
+func (m *MockContextClient) SendViewCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{address, method, opts}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "InvokeBVMContract", varargs...)
+	ret := m.ctrl.Call(m, "SendViewCtx", ctx, tx)
 	ret0, _ := ret[0].(*pb.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InvokeBVMContract indicates an expected call of InvokeBVMContract.
-func (mr *MockClientMockRecorder) InvokeBVMContract(address, method, opts interface{}, args ...interface{}) *gomock.Call {
+// SendViewCtx indicates an expected call of SendViewCtx.
+func (mr *MockContextClientMockRecorder) SendViewCtx(ctx, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{address, method, opts}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeBVMContract", reflect.TypeOf((*MockClient)(nil).InvokeBVMContract), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendViewCtx", reflect.TypeOf((*MockContextClient)(nil).SendViewCtx), ctx, tx)
 }
 
-// InvokeContract mocks base method.
-func (m *MockClient) InvokeContract(vmType pb.TransactionData_VMType, address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+// SetMasterPierCtx mocks base method.
+func (m *MockContextClient) SetMasterPierCtx(ctx context.Context, address, index string, timeout int64) (*pb.Response, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{vmType, address, method, opts}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "InvokeContract", varargs...)
-	ret0, _ := ret[0].(*pb.Receipt)
+	ret := m.ctrl.Call(m, "SetMasterPierCtx", ctx, address, index, timeout)
+	ret0, _ := ret[0].(*pb.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InvokeContract indicates an expected call of InvokeContract.
-func (mr *MockClientMockRecorder) InvokeContract(vmType, address, method, opts interface{}, args ...interface{}) *gomock.Call {
+// SetMasterPierCtx indicates an expected call of SetMasterPierCtx.
+func (mr *MockContextClientMockRecorder) SetMasterPierCtx(ctx, address, index, timeout interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{vmType, address, method, opts}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeContract", reflect.TypeOf((*MockClient)(nil).InvokeContract), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterPierCtx", reflect.TypeOf((*MockContextClient)(nil).SetMasterPierCtx), ctx, address, index, timeout)
 }
 
-// InvokeXVMContract mocks base method.
-func (m *MockClient) InvokeXVMContract(address *types.Address, method string, opts *rpcx.TransactOpts, args ...*pb.Arg) (*pb.Receipt, error) {
+// SubmitTxEnvelopeCtx mocks base method.
+func (m *MockContextClient) SubmitTxEnvelopeCtx(ctx context.Context, env *rpcx.TxEnvelope) (string, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{address, method, opts}
-	for _, a := range args {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "InvokeXVMContract", varargs...)
-	ret0, _ := ret[0].(*pb.Receipt)
+	ret := m.ctrl.Call(m, "SubmitTxEnvelopeCtx", ctx, env)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// InvokeXVMContract indicates an expected call of InvokeXVMContract.
-func (mr *MockClientMockRecorder) InvokeXVMContract(address, method, opts interface{}, args ...interface{}) *gomock.Call {
+// SubmitTxEnvelopeCtx indicates an expected call of SubmitTxEnvelopeCtx.
+func (mr *MockContextClientMockRecorder) SubmitTxEnvelopeCtx(ctx, env interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{address, method, opts}, args...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeXVMContract", reflect.TypeOf((*MockClient)(nil).InvokeXVMContract), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTxEnvelopeCtx", reflect.TypeOf((*MockContextClient)(nil).SubmitTxEnvelopeCtx), ctx, env)
 }
 
-// SendRawTransaction mocks base method.
-func (m *MockClient) SendRawTransaction(tx *pb.BxhTransaction) (string, error) {
+// VerifyInterchainTxWrappersCtx mocks base method.
+func (m *MockContextClient) VerifyInterchainTxWrappersCtx(ctx context.Context, wrappers *pb.InterchainTxWrappers) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendRawTransaction", tx)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "VerifyInterchainTxWrappersCtx", ctx, wrappers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyInterchainTxWrappersCtx indicates an expected call of VerifyInterchainTxWrappersCtx.
+func (mr *MockContextClientMockRecorder) VerifyInterchainTxWrappersCtx(ctx, wrappers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyInterchainTxWrappersCtx", reflect.TypeOf((*MockContextClient)(nil).VerifyInterchainTxWrappersCtx), ctx, wrappers)
+}
+
+// VerifyMultiSignsCtx mocks base method.
+func (m *MockContextClient) VerifyMultiSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*rpcx.MultiSignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMultiSignsCtx", ctx, content, typ, signs)
+	ret0, _ := ret[0].(*rpcx.MultiSignResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendRawTransaction indicates an expected call of SendRawTransaction.
-func (mr *MockClientMockRecorder) SendRawTransaction(tx interface{}) *gomock.Call {
+// VerifyMultiSignsCtx indicates an expected call of VerifyMultiSignsCtx.
+func (mr *MockContextClientMockRecorder) VerifyMultiSignsCtx(ctx, content, typ, signs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransaction", reflect.TypeOf((*MockClient)(nil).SendRawTransaction), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMultiSignsCtx", reflect.TypeOf((*MockContextClient)(nil).VerifyMultiSignsCtx), ctx, content, typ, signs)
 }
 
-// SendRawTransactionWithReceipt mocks base method.
-func (m *MockClient) SendRawTransactionWithReceipt(tx *pb.BxhTransaction) (*pb.Receipt, error) {
+// WaitForReceiptCtx mocks base method.
+func (m *MockContextClient) WaitForReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendRawTransactionWithReceipt", tx)
+	ret := m.ctrl.Call(m, "WaitForReceiptCtx", ctx, hash)
 	ret0, _ := ret[0].(*pb.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendRawTransactionWithReceipt indicates an expected call of SendRawTransactionWithReceipt.
-func (mr *MockClientMockRecorder) SendRawTransactionWithReceipt(tx interface{}) *gomock.Call {
+// WaitForReceiptCtx indicates an expected call of WaitForReceiptCtx.
+func (mr *MockContextClientMockRecorder) WaitForReceiptCtx(ctx, hash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRawTransactionWithReceipt", reflect.TypeOf((*MockClient)(nil).SendRawTransactionWithReceipt), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForReceiptCtx", reflect.TypeOf((*MockContextClient)(nil).WaitForReceiptCtx), ctx, hash)
 }
 
-// SendTransaction mocks base method.
-func (m *MockClient) SendTransaction(tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (string, error) {
+// WaitIBTPCtx mocks base method.
+func (m *MockContextClient) WaitIBTPCtx(ctx context.Context, id string) (pb.TransactionStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendTransaction", tx, opts)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "WaitIBTPCtx", ctx, id)
+	ret0, _ := ret[0].(pb.TransactionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendTransaction indicates an expected call of SendTransaction.
-func (mr *MockClientMockRecorder) SendTransaction(tx, opts interface{}) *gomock.Call {
+// WaitIBTPCtx indicates an expected call of WaitIBTPCtx.
+func (mr *MockContextClientMockRecorder) WaitIBTPCtx(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransaction", reflect.TypeOf((*MockClient)(nil).SendTransaction), tx, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitIBTPCtx", reflect.TypeOf((*MockContextClient)(nil).WaitIBTPCtx), ctx, id)
 }
 
-// SendTransactionWithReceipt mocks base method.
-func (m *MockClient) SendTransactionWithReceipt(tx *pb.BxhTransaction, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+// MockGovernance is a mock of Governance interface.
+type MockGovernance struct {
+	ctrl     *gomock.Controller
+	recorder *MockGovernanceMockRecorder
+}
+
+// MockGovernanceMockRecorder is the mock recorder for MockGovernance.
+type MockGovernanceMockRecorder struct {
+	mock *MockGovernance
+}
+
+// NewMockGovernance creates a new mock instance.
+func NewMockGovernance(ctrl *gomock.Controller) *MockGovernance {
+	mock := &MockGovernance{ctrl: ctrl}
+	mock.recorder = &MockGovernanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGovernance) EXPECT() *MockGovernanceMockRecorder {
+	return m.recorder
+}
+
+// ActivateAppchain mocks base method.
+func (m *MockGovernance) ActivateAppchain(ctx context.Context, chainID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendTransactionWithReceipt", tx, opts)
-	ret0, _ := ret[0].(*pb.Receipt)
+	ret := m.ctrl.Call(m, "ActivateAppchain", ctx, chainID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendTransactionWithReceipt indicates an expected call of SendTransactionWithReceipt.
-func (mr *MockClientMockRecorder) SendTransactionWithReceipt(tx, opts interface{}) *gomock.Call {
+// ActivateAppchain indicates an expected call of ActivateAppchain.
+func (mr *MockGovernanceMockRecorder) ActivateAppchain(ctx, chainID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactionWithReceipt", reflect.TypeOf((*MockClient)(nil).SendTransactionWithReceipt), tx, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateAppchain", reflect.TypeOf((*MockGovernance)(nil).ActivateAppchain), ctx, chainID, reason, opts)
 }
 
-// SendTransactions mocks base method.
-func (m *MockClient) SendTransactions(txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+// ActivateService mocks base method.
+func (m *MockGovernance) ActivateService(ctx context.Context, chainServiceID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendTransactions", txs)
-	ret0, _ := ret[0].(*pb.MultiTransactionHash)
+	ret := m.ctrl.Call(m, "ActivateService", ctx, chainServiceID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendTransactions indicates an expected call of SendTransactions.
-func (mr *MockClientMockRecorder) SendTransactions(txs interface{}) *gomock.Call {
+// ActivateService indicates an expected call of ActivateService.
+func (mr *MockGovernanceMockRecorder) ActivateService(ctx, chainServiceID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransactions", reflect.TypeOf((*MockClient)(nil).SendTransactions), txs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateService", reflect.TypeOf((*MockGovernance)(nil).ActivateService), ctx, chainServiceID, reason, opts)
 }
 
-// SendView mocks base method.
-func (m *MockClient) SendView(tx *pb.BxhTransaction) (*pb.Receipt, error) {
+// FreezeAppchain mocks base method.
+func (m *MockGovernance) FreezeAppchain(ctx context.Context, chainID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendView", tx)
-	ret0, _ := ret[0].(*pb.Receipt)
+	ret := m.ctrl.Call(m, "FreezeAppchain", ctx, chainID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SendView indicates an expected call of SendView.
-func (mr *MockClientMockRecorder) SendView(tx interface{}) *gomock.Call {
+// FreezeAppchain indicates an expected call of FreezeAppchain.
+func (mr *MockGovernanceMockRecorder) FreezeAppchain(ctx, chainID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendView", reflect.TypeOf((*MockClient)(nil).SendView), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeAppchain", reflect.TypeOf((*MockGovernance)(nil).FreezeAppchain), ctx, chainID, reason, opts)
 }
 
-// SetMasterPier mocks base method.
-func (m *MockClient) SetMasterPier(address, index string, timeout int64) (*pb.Response, error) {
+// FreezeService mocks base method.
+func (m *MockGovernance) FreezeService(ctx context.Context, chainServiceID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetMasterPier", address, index, timeout)
-	ret0, _ := ret[0].(*pb.Response)
+	ret := m.ctrl.Call(m, "FreezeService", ctx, chainServiceID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SetMasterPier indicates an expected call of SetMasterPier.
-func (mr *MockClientMockRecorder) SetMasterPier(address, index, timeout interface{}) *gomock.Call {
+// FreezeService indicates an expected call of FreezeService.
+func (mr *MockGovernanceMockRecorder) FreezeService(ctx, chainServiceID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMasterPier", reflect.TypeOf((*MockClient)(nil).SetMasterPier), address, index, timeout)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeService", reflect.TypeOf((*MockGovernance)(nil).FreezeService), ctx, chainServiceID, reason, opts)
 }
 
-// SetPrivateKey mocks base method.
-func (m *MockClient) SetPrivateKey(arg0 crypto.PrivateKey) {
+// LogoutAppchain mocks base method.
+func (m *MockGovernance) LogoutAppchain(ctx context.Context, chainID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetPrivateKey", arg0)
+	ret := m.ctrl.Call(m, "LogoutAppchain", ctx, chainID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetPrivateKey indicates an expected call of SetPrivateKey.
-func (mr *MockClientMockRecorder) SetPrivateKey(arg0 interface{}) *gomock.Call {
+// LogoutAppchain indicates an expected call of LogoutAppchain.
+func (mr *MockGovernanceMockRecorder) LogoutAppchain(ctx, chainID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrivateKey", reflect.TypeOf((*MockClient)(nil).SetPrivateKey), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAppchain", reflect.TypeOf((*MockGovernance)(nil).LogoutAppchain), ctx, chainID, reason, opts)
 }
 
-// Stop mocks base method.
-func (m *MockClient) Stop() error {
+// LogoutNode mocks base method.
+func (m *MockGovernance) LogoutNode(ctx context.Context, nodeAccount, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Stop")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "LogoutNode", ctx, nodeAccount, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Stop indicates an expected call of Stop.
-func (mr *MockClientMockRecorder) Stop() *gomock.Call {
+// LogoutNode indicates an expected call of LogoutNode.
+func (mr *MockGovernanceMockRecorder) LogoutNode(ctx, nodeAccount, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockClient)(nil).Stop))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutNode", reflect.TypeOf((*MockGovernance)(nil).LogoutNode), ctx, nodeAccount, reason, opts)
 }
 
-// Subscribe mocks base method.
-func (m *MockClient) Subscribe(arg0 context.Context, arg1 pb.SubscriptionRequest_Type, arg2 []byte) (<-chan interface{}, error) {
+// LogoutService mocks base method.
+func (m *MockGovernance) LogoutService(ctx context.Context, chainServiceID, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1, arg2)
-	ret0, _ := ret[0].(<-chan interface{})
+	ret := m.ctrl.Call(m, "LogoutService", ctx, chainServiceID, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Subscribe indicates an expected call of Subscribe.
-func (mr *MockClientMockRecorder) Subscribe(arg0, arg1, arg2 interface{}) *gomock.Call {
+// LogoutService indicates an expected call of LogoutService.
+func (mr *MockGovernanceMockRecorder) LogoutService(ctx, chainServiceID, reason, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockClient)(nil).Subscribe), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutService", reflect.TypeOf((*MockGovernance)(nil).LogoutService), ctx, chainServiceID, reason, opts)
 }
 
-// SubscribeAudit mocks base method.
-func (m *MockClient) SubscribeAudit(arg0 context.Context, arg1 pb.AuditSubscriptionRequest_Type, arg2 uint64, arg3 []byte) (<-chan interface{}, error) {
+// RegisterAppchain mocks base method.
+func (m *MockGovernance) RegisterAppchain(ctx context.Context, req *rpcx.RegisterAppchainRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubscribeAudit", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(<-chan interface{})
+	ret := m.ctrl.Call(m, "RegisterAppchain", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SubscribeAudit indicates an expected call of SubscribeAudit.
-func (mr *MockClientMockRecorder) SubscribeAudit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// RegisterAppchain indicates an expected call of RegisterAppchain.
+func (mr *MockGovernanceMockRecorder) RegisterAppchain(ctx, req, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeAudit", reflect.TypeOf((*MockClient)(nil).SubscribeAudit), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterAppchain", reflect.TypeOf((*MockGovernance)(nil).RegisterAppchain), ctx, req, opts)
+}
+
+// RegisterNode mocks base method.
+func (m *MockGovernance) RegisterNode(ctx context.Context, req *rpcx.RegisterNodeRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterNode", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterNode indicates an expected call of RegisterNode.
+func (mr *MockGovernanceMockRecorder) RegisterNode(ctx, req, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNode", reflect.TypeOf((*MockGovernance)(nil).RegisterNode), ctx, req, opts)
+}
+
+// RegisterRule mocks base method.
+func (m *MockGovernance) RegisterRule(ctx context.Context, req *rpcx.RegisterRuleRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterRule", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterRule indicates an expected call of RegisterRule.
+func (mr *MockGovernanceMockRecorder) RegisterRule(ctx, req, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRule", reflect.TypeOf((*MockGovernance)(nil).RegisterRule), ctx, req, opts)
+}
+
+// RegisterService mocks base method.
+func (m *MockGovernance) RegisterService(ctx context.Context, req *rpcx.RegisterServiceRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterService", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterService indicates an expected call of RegisterService.
+func (mr *MockGovernanceMockRecorder) RegisterService(ctx, req, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterService", reflect.TypeOf((*MockGovernance)(nil).RegisterService), ctx, req, opts)
+}
+
+// UpdateAppchain mocks base method.
+func (m *MockGovernance) UpdateAppchain(ctx context.Context, req *rpcx.UpdateAppchainRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAppchain", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAppchain indicates an expected call of UpdateAppchain.
+func (mr *MockGovernanceMockRecorder) UpdateAppchain(ctx, req, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAppchain", reflect.TypeOf((*MockGovernance)(nil).UpdateAppchain), ctx, req, opts)
+}
+
+// UpdateMasterRule mocks base method.
+func (m *MockGovernance) UpdateMasterRule(ctx context.Context, chainID, ruleAddr, reason string, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMasterRule", ctx, chainID, ruleAddr, reason, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMasterRule indicates an expected call of UpdateMasterRule.
+func (mr *MockGovernanceMockRecorder) UpdateMasterRule(ctx, chainID, ruleAddr, reason, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMasterRule", reflect.TypeOf((*MockGovernance)(nil).UpdateMasterRule), ctx, chainID, ruleAddr, reason, opts)
+}
+
+// UpdateService mocks base method.
+func (m *MockGovernance) UpdateService(ctx context.Context, req *rpcx.UpdateServiceRequest, opts *rpcx.TransactOpts) (*rpcx.GovernanceResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateService", ctx, req, opts)
+	ret0, _ := ret[0].(*rpcx.GovernanceResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateService indicates an expected call of UpdateService.
+func (mr *MockGovernanceMockRecorder) UpdateService(ctx, req, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockGovernance)(nil).UpdateService), ctx, req, opts)
+}
+
+// MockProposals is a mock of Proposals interface.
+type MockProposals struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalsMockRecorder
+}
+
+// MockProposalsMockRecorder is the mock recorder for MockProposals.
+type MockProposalsMockRecorder struct {
+	mock *MockProposals
+}
+
+// NewMockProposals creates a new mock instance.
+func NewMockProposals(ctrl *gomock.Controller) *MockProposals {
+	mock := &MockProposals{ctrl: ctrl}
+	mock.recorder = &MockProposalsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposals) EXPECT() *MockProposalsMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockProposals) Get(ctx context.Context, id string) (*rpcx.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProposalsMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProposals)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockProposals) List(ctx context.Context, filter rpcx.ProposalFilter) ([]*rpcx.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*rpcx.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProposalsMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProposals)(nil).List), ctx, filter)
+}
+
+// Vote mocks base method.
+func (m *MockProposals) Vote(ctx context.Context, id string, approve bool, reason string, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", ctx, id, approve, reason, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockProposalsMockRecorder) Vote(ctx, id, approve, reason, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockProposals)(nil).Vote), ctx, id, approve, reason, opts)
+}
+
+// WaitProposal mocks base method.
+func (m *MockProposals) WaitProposal(ctx context.Context, id string) (*rpcx.Proposal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitProposal", ctx, id)
+	ret0, _ := ret[0].(*rpcx.Proposal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitProposal indicates an expected call of WaitProposal.
+func (mr *MockProposalsMockRecorder) WaitProposal(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitProposal", reflect.TypeOf((*MockProposals)(nil).WaitProposal), ctx, id)
+}
+
+// Withdraw mocks base method.
+func (m *MockProposals) Withdraw(ctx context.Context, id, reason string, opts *rpcx.TransactOpts) (*pb.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, id, reason, opts)
+	ret0, _ := ret[0].(*pb.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockProposalsMockRecorder) Withdraw(ctx, id, reason, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockProposals)(nil).Withdraw), ctx, id, reason, opts)
 }
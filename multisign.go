@@ -0,0 +1,273 @@
+package rpcx
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+var (
+	// ErrQuorumNotReached is returned when fewer validators than the threshold signed validly
+	ErrQuorumNotReached = errors.New("multi-sign quorum not reached")
+
+	// ErrUnsupportedSignType is returned when the signed digest of a sign type can't be rebuilt
+	ErrUnsupportedSignType = errors.New("unsupported sign type")
+)
+
+// SignDigestFunc rebuilds the digest the validators signed for the content of a
+// GetMultiSigns request.
+type SignDigestFunc func(ctx context.Context, cli *ChainClient, content string) ([]byte, error)
+
+// MultiSignResult is the outcome of verifying multi-signatures against a validator set.
+type MultiSignResult struct {
+	Digest    []byte
+	Threshold int
+	// Valid lists the validators whose signature verifies
+	Valid []string
+	// Invalid lists the signers whose signature doesn't verify or who are not validators
+	Invalid []string
+	// Missing lists the validators who didn't sign
+	Missing []string
+}
+
+// QuorumReached reports whether at least Threshold validators signed validly.
+func (r *MultiSignResult) QuorumReached() bool {
+	return len(r.Valid) >= r.Threshold
+}
+
+// QuorumThreshold returns 2f+1 for n validators tolerating f = (n-1)/3 faulty ones.
+func QuorumThreshold(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	return 2*((n-1)/3) + 1
+}
+
+// VerifyMultiSigns checks every signature in signs over digest against the validator set,
+// in which a validator listed more than once counts once. A signer which is not a valid
+// address is invalid, and a validator which is not one is missing.
+// Signatures are accepted both as raw secp256k1 signatures and with a key type prefix.
+func VerifyMultiSigns(digest []byte, signs map[string][]byte, validators []string, threshold int) *MultiSignResult {
+	result := &MultiSignResult{
+		Digest:    digest,
+		Threshold: threshold,
+	}
+
+	signed := make(map[string][]byte, len(signs))
+	for signer, sig := range signs {
+		addr, ok := normalizeAddress(signer)
+		if !ok {
+			result.Invalid = append(result.Invalid, signer)
+			continue
+		}
+		signed[addr] = sig
+	}
+
+	isValidator := make(map[string]bool, len(validators))
+	for _, validator := range validators {
+		addr, ok := normalizeAddress(validator)
+		if !ok {
+			result.Missing = append(result.Missing, validator)
+			continue
+		}
+		if isValidator[addr] {
+			continue
+		}
+		isValidator[addr] = true
+		sig, ok := signed[addr]
+		switch {
+		case !ok:
+			result.Missing = append(result.Missing, addr)
+		case verifySign(sig, digest, addr):
+			result.Valid = append(result.Valid, addr)
+		default:
+			result.Invalid = append(result.Invalid, addr)
+		}
+	}
+	for signer := range signed {
+		if !isValidator[signer] {
+			result.Invalid = append(result.Invalid, signer)
+		}
+	}
+
+	sort.Strings(result.Valid)
+	sort.Strings(result.Invalid)
+	sort.Strings(result.Missing)
+	return result
+}
+
+func (cli *ChainClient) VerifyMultiSigns(content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*MultiSignResult, error) {
+	return cli.VerifyMultiSignsCtx(context.Background(), content, typ, signs)
+}
+
+// VerifyMultiSignsCtx rebuilds the digest signed for content, verifies signs against
+// the current validators and enforces the 2f+1 quorum. The result is returned even
+// if the quorum is not reached, together with an error wrapping ErrQuorumNotReached.
+func (cli *ChainClient) VerifyMultiSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type, signs *pb.SignResponse) (*MultiSignResult, error) {
+	if signs == nil {
+		return nil, fmt.Errorf("sign response is nil")
+	}
+
+	digestFn, ok := cli.signDigests[typ]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSignType, typ)
+	}
+	digest, err := digestFn(ctx, cli, content)
+	if err != nil {
+		return nil, fmt.Errorf("rebuild digest of %s %s: %w", typ, content, err)
+	}
+
+	validators, err := cli.validatorAddrs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := VerifyMultiSigns(digest, signs.Sign, validators, QuorumThreshold(len(validators)))
+	if !result.QuorumReached() {
+		return result, fmt.Errorf("%w: %d valid signatures of %d validators, need %d",
+			ErrQuorumNotReached, len(result.Valid), len(validators), result.Threshold)
+	}
+	return result, nil
+}
+
+func (cli *ChainClient) validatorAddrs(ctx context.Context) ([]string, error) {
+	resp, err := cli.GetValidatorsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []string
+	if err := json.Unmarshal(resp.Data, &addrs); err != nil {
+		return nil, fmt.Errorf("unmarshal validators: %w", err)
+	}
+
+	// a validator listed twice must not lower the share of the others in the quorum
+	validators := make([]string, 0, len(addrs))
+	seen := make(map[string]bool, len(addrs))
+	for _, validator := range addrs {
+		addr, ok := normalizeAddress(validator)
+		if !ok {
+			return nil, fmt.Errorf("invalid validator address %q", validator)
+		}
+		if !seen[addr] {
+			seen[addr] = true
+			validators = append(validators, addr)
+		}
+	}
+	return validators, nil
+}
+
+// defaultSignDigests rebuilds the digests whose signed content is fully known
+// from the chain. Others can be registered with WithSignDigest: the asset
+// exchange and burn contents are signed over data of the appchains, and TSS
+// signs are one aggregated signature instead of one per validator.
+func defaultSignDigests() map[pb.GetSignsRequest_Type]SignDigestFunc {
+	return map[pb.GetSignsRequest_Type]SignDigestFunc{
+		pb.GetSignsRequest_MULTI_IBTP_REQUEST:  ibtpSignDigest(true),
+		pb.GetSignsRequest_MULTI_IBTP_RESPONSE: ibtpSignDigest(false),
+		pb.GetSignsRequest_MULTI_BLOCK_HEADER:  blockHeaderSignDigest,
+	}
+}
+
+// IBTPSignDigest returns the digest validators sign for ibtp with the status of
+// its interchain transaction, the sha256 of from, to, index, type, the payload
+// hash and the status packed as BitXHub packs them.
+func IBTPSignDigest(ibtp *pb.IBTP, status pb.TransactionStatus) ([]byte, error) {
+	payload := &pb.Payload{}
+	if err := payload.Unmarshal(ibtp.Payload); err != nil {
+		return nil, fmt.Errorf("unmarshal payload of ibtp %s: %w", ibtp.ID(), err)
+	}
+
+	uint64Bytes := func(i uint64) []byte {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, i)
+		return b
+	}
+	var data []byte
+	data = append(data, ibtp.From...)
+	data = append(data, ibtp.To...)
+	data = append(data, uint64Bytes(ibtp.Index)...)
+	data = append(data, uint64Bytes(uint64(ibtp.Type))...)
+	data = append(data, payload.Hash...)
+	data = append(data, uint64Bytes(uint64(status))...)
+
+	hash := sha256.Sum256(data)
+	return hash[:], nil
+}
+
+// ibtpSignDigest is the IBTPSignDigest of the IBTP with the ID given as content,
+// the interchain request if isReq or else its receipt, with its current status.
+func ibtpSignDigest(isReq bool) SignDigestFunc {
+	return func(ctx context.Context, cli *ChainClient, content string) ([]byte, error) {
+		ret, err := cli.viewBVM(ctx, constant.InterchainContractAddr, "GetIBTPByID", String(content), Bool(isReq))
+		if err != nil {
+			return nil, err
+		}
+		// the hash of the transaction is returned either raw or hex encoded
+		hash := string(ret)
+		if len(ret) == types.HashLength {
+			hash = types.NewHash(ret).String()
+		}
+		tx, err := cli.GetTransactionCtx(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		ibtp := tx.GetTx().GetIBTP()
+		if ibtp == nil || ibtp.ID() != content {
+			return nil, fmt.Errorf("transaction %s doesn't carry ibtp %s", hash, content)
+		}
+		status, err := cli.GetIBTPStatusCtx(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		return IBTPSignDigest(ibtp, status)
+	}
+}
+
+// blockHeaderSignDigest is the hash of the block header at the height given as content.
+func blockHeaderSignDigest(ctx context.Context, cli *ChainClient, content string) ([]byte, error) {
+	height, err := strconv.ParseUint(content, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height %s: %w", content, err)
+	}
+	header, err := cli.blockHeaderAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return header.Hash().Bytes(), nil
+}
+
+func verifySign(sig, digest []byte, addr string) bool {
+	if len(sig) == 0 {
+		return false
+	}
+	from := types.NewAddressByStr(addr)
+	if from == nil {
+		return false
+	}
+	if ok, err := asym.Verify(crypto.Secp256k1, sig, digest, *from); err == nil && ok {
+		return true
+	}
+	ok, err := asym.VerifyWithType(sig, digest, *from)
+	return err == nil && ok
+}
+
+// normalizeAddress returns the checksummed form of addr, or false if addr is
+// not an address.
+func normalizeAddress(addr string) (string, bool) {
+	address := types.NewAddressByStr(addr)
+	if address == nil {
+		return "", false
+	}
+	return address.String(), true
+}
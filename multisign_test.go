@@ -0,0 +1,220 @@
+package rpcx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumThreshold(t *testing.T) {
+	for n, threshold := range map[int]int{0: 1, 1: 1, 3: 1, 4: 3, 6: 3, 7: 5, 10: 7} {
+		require.Equal(t, threshold, QuorumThreshold(n), "n = %d", n)
+	}
+}
+
+func newTestValidators(t *testing.T, n int) ([]crypto.PrivateKey, []string) {
+	var (
+		keys  []crypto.PrivateKey
+		addrs []string
+	)
+	for i := 0; i < n; i++ {
+		key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+		require.Nil(t, err)
+		addr, err := key.PublicKey().Address()
+		require.Nil(t, err)
+		keys = append(keys, key)
+		addrs = append(addrs, addr.String())
+	}
+	return keys, addrs
+}
+
+func TestVerifyMultiSigns(t *testing.T) {
+	keys, validators := newTestValidators(t, 4)
+	outsiders, outsiderAddrs := newTestValidators(t, 1)
+	digest := types.NewHash([]byte("digest")).Bytes()
+
+	signs := make(map[string][]byte)
+	sig, err := keys[0].Sign(digest)
+	require.Nil(t, err)
+	signs[validators[0]] = sig
+	sig, err = asym.SignWithType(keys[1], digest)
+	require.Nil(t, err)
+	signs[validators[1]] = sig
+	sig, err = keys[2].Sign(types.NewHash([]byte("other")).Bytes())
+	require.Nil(t, err)
+	signs[validators[2]] = sig
+	sig, err = outsiders[0].Sign(digest)
+	require.Nil(t, err)
+	signs[outsiderAddrs[0]] = sig
+
+	result := VerifyMultiSigns(digest, signs, validators, QuorumThreshold(len(validators)))
+	require.ElementsMatch(t, validators[:2], result.Valid)
+	require.ElementsMatch(t, []string{validators[2], outsiderAddrs[0]}, result.Invalid)
+	require.Equal(t, []string{validators[3]}, result.Missing)
+	require.False(t, result.QuorumReached())
+
+	sig, err = keys[3].Sign(digest)
+	require.Nil(t, err)
+	signs[validators[3]] = sig
+	result = VerifyMultiSigns(digest, signs, validators, QuorumThreshold(len(validators)))
+	require.Len(t, result.Valid, 3)
+	require.Len(t, result.Missing, 0)
+	require.True(t, result.QuorumReached())
+}
+
+func TestVerifyMultiSignsMalformed(t *testing.T) {
+	keys, validators := newTestValidators(t, 4)
+	digest := types.NewHash([]byte("digest")).Bytes()
+	signs := make(map[string][]byte)
+	for i := 0; i < 3; i++ {
+		sig, err := keys[i].Sign(digest)
+		require.Nil(t, err)
+		signs[validators[i]] = sig
+	}
+	signs["garbage"] = []byte("sig")
+
+	// malformed signers and validators never count toward the quorum
+	result := VerifyMultiSigns(digest, signs, append(validators, "0x12"), QuorumThreshold(len(validators)))
+	require.ElementsMatch(t, validators[:3], result.Valid)
+	require.Equal(t, []string{"garbage"}, result.Invalid)
+	require.ElementsMatch(t, []string{validators[3], "0x12"}, result.Missing)
+	require.True(t, result.QuorumReached())
+}
+
+// validatorBroker serves a validator set.
+type validatorBroker struct {
+	*rpcxtest.Broker
+	validators []string
+}
+
+func (b *validatorBroker) GetInfo(_ context.Context, req *pb.Request) (*pb.Response, error) {
+	data, err := json.Marshal(b.validators)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Response{Data: data}, nil
+}
+
+func TestChainClient_VerifyMultiSigns(t *testing.T) {
+	keys, validators := newTestValidators(t, 4)
+	broker := &validatorBroker{Broker: rpcxtest.NewBroker(), validators: validators}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker})
+	header := headerAt(t, broker.Broker, 1)
+
+	signs := &pb.SignResponse{Sign: make(map[string][]byte)}
+	for i := 0; i < 2; i++ {
+		sig, err := keys[i].Sign(header.Hash().Bytes())
+		require.Nil(t, err)
+		signs.Sign[validators[i]] = sig
+	}
+
+	result, err := cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BLOCK_HEADER, signs)
+	require.ErrorIs(t, err, ErrQuorumNotReached)
+	require.Len(t, result.Missing, 2)
+
+	sig, err := keys[2].Sign(header.Hash().Bytes())
+	require.Nil(t, err)
+	signs.Sign[validators[2]] = sig
+	result, err = cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BLOCK_HEADER, signs)
+	require.Nil(t, err)
+	require.Equal(t, validators[3:], result.Missing)
+
+	_, err = cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BURN, signs)
+	require.ErrorIs(t, err, ErrUnsupportedSignType)
+
+	// a malformed signer reported by the node is invalid
+	signs.Sign["garbage"] = sig
+	result, err = cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BLOCK_HEADER, signs)
+	require.Nil(t, err)
+	require.Equal(t, []string{"garbage"}, result.Invalid)
+}
+
+func TestChainClient_VerifyMultiSignsMalformedValidator(t *testing.T) {
+	keys, validators := newTestValidators(t, 1)
+	broker := &validatorBroker{Broker: rpcxtest.NewBroker(), validators: append(validators, "garbage")}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker})
+	header := headerAt(t, broker.Broker, 1)
+
+	sig, err := keys[0].Sign(header.Hash().Bytes())
+	require.Nil(t, err)
+	signs := &pb.SignResponse{Sign: map[string][]byte{validators[0]: sig}}
+	_, err = cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BLOCK_HEADER, signs)
+	require.ErrorContains(t, err, "invalid validator address")
+}
+
+func TestChainClient_VerifyMultiSignsIBTP(t *testing.T) {
+	keys, validators := newTestValidators(t, 4)
+	broker := &validatorBroker{Broker: rpcxtest.NewBroker(), validators: validators}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker})
+
+	request := NewIBTPBuilder(testFromService, testToService, 1).Call("interchainCharge", []byte("Alice"))
+	receipt, err := cli.SendIBTPCtx(context.Background(), request, nil)
+	require.Nil(t, err)
+	ibtp, err := request.Build()
+	require.Nil(t, err)
+	broker.HandleInvoke(constant.InterchainContractAddr.Address(), func(_ *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error) {
+		if method != "GetIBTPByID" || string(args[0].Value) != ibtp.ID() || string(args[1].Value) != "true" {
+			return nil, fmt.Errorf("ibtp %s doesn't exist", args[0].Value)
+		}
+		return receipt.TxHash.Bytes(), nil
+	})
+
+	digest, err := IBTPSignDigest(ibtp, pb.TransactionStatus_BEGIN)
+	require.Nil(t, err)
+	signs := &pb.SignResponse{Sign: make(map[string][]byte)}
+	for i := 0; i < 3; i++ {
+		sig, err := keys[i].Sign(digest)
+		require.Nil(t, err)
+		signs.Sign[validators[i]] = sig
+	}
+	result, err := cli.VerifyMultiSigns(ibtp.ID(), pb.GetSignsRequest_MULTI_IBTP_REQUEST, signs)
+	require.Nil(t, err)
+	require.Equal(t, digest, result.Digest)
+
+	// the signs of another status don't verify
+	digest, err = IBTPSignDigest(ibtp, pb.TransactionStatus_SUCCESS)
+	require.Nil(t, err)
+	sig, err := keys[0].Sign(digest)
+	require.Nil(t, err)
+	signs.Sign[validators[0]] = sig
+	_, err = cli.VerifyMultiSigns(ibtp.ID(), pb.GetSignsRequest_MULTI_IBTP_REQUEST, signs)
+	require.ErrorIs(t, err, ErrQuorumNotReached)
+
+	_, err = cli.VerifyMultiSigns(ibtp.ID(), pb.GetSignsRequest_MULTI_IBTP_RESPONSE, signs)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestChainClient_VerifyMultiSignsDuplicateValidators(t *testing.T) {
+	keys, validators := newTestValidators(t, 4)
+	// a validator listed three times still needs two others to reach the quorum
+	listed := append([]string{validators[0], validators[0]}, validators...)
+	broker := &validatorBroker{Broker: rpcxtest.NewBroker(), validators: listed}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker})
+	header := headerAt(t, broker.Broker, 1)
+
+	sig, err := keys[0].Sign(header.Hash().Bytes())
+	require.Nil(t, err)
+	signs := &pb.SignResponse{Sign: map[string][]byte{validators[0]: sig}}
+	result, err := cli.VerifyMultiSigns("1", pb.GetSignsRequest_MULTI_BLOCK_HEADER, signs)
+	require.ErrorIs(t, err, ErrQuorumNotReached)
+	require.Equal(t, 3, result.Threshold)
+	require.Len(t, result.Valid, 1)
+	require.Len(t, result.Missing, 3)
+}
@@ -2,7 +2,6 @@ package rpcx
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/meshplus/bitxhub-model/pb"
@@ -13,51 +12,33 @@ const (
 )
 
 func (cli *ChainClient) GetValidators() (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetInfoTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetValidatorsCtx(context.Background())
+}
 
-	grpcClient, err := cli.pool.getClient()
+func (cli *ChainClient) GetValidatorsCtx(ctx context.Context) (*pb.Response, error) {
+	var response *pb.Response
+	err := cli.call(ctx, "GetInfo", GetInfoTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetInfo(ctx, &pb.Request{Type: pb.Request_VALIDATORS})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetInfo(ctx, &pb.Request{Type: pb.Request_VALIDATORS})
-	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
 	return response, nil
 }
 
 func (cli *ChainClient) GetNetworkMeta() (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetInfoTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetNetworkMetaCtx(context.Background())
+}
 
-	grpcClient, err := cli.pool.getClient()
+func (cli *ChainClient) GetNetworkMetaCtx(ctx context.Context) (*pb.Response, error) {
+	var response *pb.Response
+	err := cli.call(ctx, "GetInfo", GetInfoTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetInfo(ctx, &pb.Request{Type: pb.Request_NETWORK})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetInfo(ctx, &pb.Request{Type: pb.Request_NETWORK})
-	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
 	return response, nil
 }
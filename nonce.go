@@ -0,0 +1,162 @@
+package rpcx
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// NonceFetcher returns the pending nonce of an account from BitXHub.
+type NonceFetcher func(ctx context.Context, account string) (uint64, error)
+
+// NonceManager caches the next nonce of every account it has seen, so that
+// concurrent senders get distinct nonces without asking BitXHub each time.
+type NonceManager struct {
+	fetch    NonceFetcher
+	mu       sync.Mutex
+	accounts map[string]*accountNonce
+}
+
+type accountNonce struct {
+	mu       sync.Mutex
+	synced   bool
+	base     uint64 // pending nonce of the last sync
+	next     uint64
+	released []uint64 // nonces handed out but never sent, kept sorted
+	inflight int      // nonces handed out but not settled yet
+	stale    bool     // the cache is dropped once no nonce is in flight
+}
+
+func NewNonceManager(fetch NonceFetcher) *NonceManager {
+	return &NonceManager{
+		fetch:    fetch,
+		accounts: make(map[string]*accountNonce),
+	}
+}
+
+func (m *NonceManager) account(account string) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	an, ok := m.accounts[account]
+	if !ok {
+		an = &accountNonce{}
+		m.accounts[account] = an
+	}
+	return an
+}
+
+// Next returns the nonce the next transaction of account should use.
+// Released nonces are reused first, lowest first. Every nonce returned must be
+// settled by Sent, Release or Invalidate.
+func (m *NonceManager) Next(ctx context.Context, account string) (uint64, error) {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+
+	if !an.synced {
+		if err := m.sync(ctx, account, an); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(an.released) != 0 {
+		nonce := an.released[0]
+		an.released = an.released[1:]
+		an.inflight++
+		return nonce, nil
+	}
+	nonce := an.next
+	an.next++
+	an.inflight++
+	return nonce, nil
+}
+
+// Sent settles a nonce returned by Next whose transaction was accepted by
+// BitXHub.
+func (m *NonceManager) Sent(account string) {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	an.settle()
+}
+
+// Invalidate settles a nonce returned by Next whose transaction may or may not
+// have reached BitXHub, like when the send timed out. Neither reusing nor
+// skipping the nonce is safe then, so the cache of account is reloaded from
+// BitXHub once the transactions sent with the other nonces in flight are
+// settled too, and the pending nonce accounts for all of them.
+func (m *NonceManager) Invalidate(account string) {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	an.stale = true
+	an.settle()
+}
+
+// Release gives back a nonce returned by Next whose transaction never reached
+// BitXHub, so that it can be reused and leaves no gap. A nonce handed out
+// before the last sync is ignored, as the sync accounts for it already.
+func (m *NonceManager) Release(account string, nonce uint64) {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	defer an.settle()
+
+	if !an.synced || nonce < an.base || nonce >= an.next {
+		return
+	}
+	if nonce == an.next-1 {
+		an.next--
+		return
+	}
+	i := sort.Search(len(an.released), func(i int) bool { return an.released[i] >= nonce })
+	if i < len(an.released) && an.released[i] == nonce {
+		return
+	}
+	an.released = append(an.released, 0)
+	copy(an.released[i+1:], an.released[i:])
+	an.released[i] = nonce
+}
+
+// Resync drops the cached nonce of account and reloads it from BitXHub.
+func (m *NonceManager) Resync(ctx context.Context, account string) error {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	return m.sync(ctx, account, an)
+}
+
+// Reset drops the cached nonce of account, it is reloaded on next use.
+func (m *NonceManager) Reset(account string) {
+	an := m.account(account)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	an.synced = false
+	an.released = nil
+}
+
+func (m *NonceManager) sync(ctx context.Context, account string, an *accountNonce) error {
+	nonce, err := m.fetch(ctx, account)
+	if err != nil {
+		an.synced = false
+		return err
+	}
+	an.base = nonce
+	an.next = nonce
+	an.released = nil
+	an.synced = true
+	return nil
+}
+
+// settle counts a nonce in flight as done, and drops the cache if it is stale
+// and no other nonce is in flight.
+func (an *accountNonce) settle() {
+	if an.inflight > 0 {
+		an.inflight--
+	}
+	if an.stale && an.inflight == 0 {
+		an.stale = false
+		an.synced = false
+		an.released = nil
+	}
+}
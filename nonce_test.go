@@ -0,0 +1,292 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNonceManager_Next(t *testing.T) {
+	var fetched uint64
+	manager := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+		atomic.AddUint64(&fetched, 1)
+		return 5, nil
+	})
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		nonces = make(map[uint64]bool)
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := manager.Next(context.Background(), "alice")
+			require.Nil(t, err)
+			mu.Lock()
+			nonces[nonce] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, nonces, 50)
+	for i := uint64(5); i < 55; i++ {
+		require.True(t, nonces[i])
+	}
+	require.Equal(t, uint64(1), atomic.LoadUint64(&fetched))
+}
+
+func TestNonceManager_Release(t *testing.T) {
+	manager := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+		return 1, nil
+	})
+	ctx := context.Background()
+
+	for i := uint64(1); i <= 4; i++ {
+		nonce, err := manager.Next(ctx, "alice")
+		require.Nil(t, err)
+		require.Equal(t, i, nonce)
+	}
+
+	// releasing the latest nonce just steps back
+	manager.Release("alice", 4)
+	nonce, err := manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(4), nonce)
+
+	// gaps are reused lowest first
+	manager.Release("alice", 3)
+	manager.Release("alice", 2)
+	manager.Release("alice", 2)
+	for _, expected := range []uint64{2, 3, 5} {
+		nonce, err := manager.Next(ctx, "alice")
+		require.Nil(t, err)
+		require.Equal(t, expected, nonce)
+	}
+
+	// other accounts are not affected
+	nonce, err = manager.Next(ctx, "bob")
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), nonce)
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	pending := uint64(1)
+	manager := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+		if pending == 0 {
+			return 0, fmt.Errorf("node is down")
+		}
+		return pending, nil
+	})
+	ctx := context.Background()
+
+	_, err := manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	manager.Release("alice", 0)
+
+	pending = 10
+	require.Nil(t, manager.Resync(ctx, "alice"))
+	nonce, err := manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), nonce)
+
+	pending = 0
+	require.NotNil(t, manager.Resync(ctx, "alice"))
+	_, err = manager.Next(ctx, "alice")
+	require.NotNil(t, err)
+
+	// a reset keeps the cache of the account, so that senders holding it
+	// don't get nonces of another one
+	pending = 20
+	an := manager.accounts["alice"]
+	manager.Reset("alice")
+	require.Same(t, an, manager.accounts["alice"])
+	nonce, err = manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(20), nonce)
+
+	// a nonce handed out before a sync is not released into the new one
+	pending = 30
+	require.Nil(t, manager.Resync(ctx, "alice"))
+	manager.Release("alice", 20)
+	nonce, err = manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(30), nonce)
+}
+
+func TestNonceManager_Invalidate(t *testing.T) {
+	pending := uint64(1)
+	manager := NewNonceManager(func(ctx context.Context, account string) (uint64, error) {
+		return pending, nil
+	})
+	ctx := context.Background()
+
+	for i := uint64(1); i <= 3; i++ {
+		nonce, err := manager.Next(ctx, "alice")
+		require.Nil(t, err)
+		require.Equal(t, i, nonce)
+	}
+
+	// the cache is kept while other nonces are in flight
+	pending = 10
+	manager.Invalidate("alice")
+	nonce, err := manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(4), nonce)
+	manager.Sent("alice")
+	manager.Release("alice", 3)
+	nonce, err = manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), nonce)
+	manager.Sent("alice")
+
+	// and reloaded once they are all settled
+	manager.Sent("alice")
+	nonce, err = manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), nonce)
+	manager.Sent("alice")
+	nonce, err = manager.Next(ctx, "alice")
+	require.Nil(t, err)
+	require.Equal(t, uint64(11), nonce)
+}
+
+// nonceBroker counts the queries of pending nonces.
+type nonceBroker struct {
+	*rpcxtest.Broker
+	fetched uint64
+}
+
+func (b *nonceBroker) GetPendingNonceByAccount(ctx context.Context, addr *pb.Address) (*pb.Response, error) {
+	atomic.AddUint64(&b.fetched, 1)
+	return b.Broker.GetPendingNonceByAccount(ctx, addr)
+}
+
+func TestChainClient_NonceManager(t *testing.T) {
+	broker := &nonceBroker{Broker: rpcxtest.NewBroker()}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, from := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker}, WithNonceManager())
+
+	for i := 0; i < 5; i++ {
+		_, err := sendTestTransfer(cli)
+		require.Nil(t, err)
+	}
+	require.Equal(t, uint64(1), atomic.LoadUint64(&broker.fetched))
+
+	// someone else sends with the same account, so the cached nonce is stale
+	broker.SetNonce(from, 8)
+	_, err := sendTestTransfer(cli)
+	require.ErrorIs(t, err, ErrNonceTooLow)
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), atomic.LoadUint64(&broker.fetched))
+}
+
+// flakyBroker breaks the connection of the failAt-th call of SendTransaction,
+// after handling it if lost.
+type flakyBroker struct {
+	*rpcxtest.Broker
+	calls  int64
+	failAt int64
+	lost   bool
+}
+
+func (b *flakyBroker) SendTransaction(ctx context.Context, tx *pb.BxhTransaction) (*pb.TransactionHashMsg, error) {
+	if atomic.AddInt64(&b.calls, 1) != b.failAt {
+		return b.Broker.SendTransaction(ctx, tx)
+	}
+	if b.lost {
+		if _, err := b.Broker.SendTransaction(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+	return nil, status.Error(codes.Unavailable, "connection reset")
+}
+
+func TestChainClient_NonceManagerConcurrentFailure(t *testing.T) {
+	for _, lost := range []bool{false, true} {
+		t.Run(fmt.Sprintf("lost=%v", lost), func(t *testing.T) {
+			broker := &flakyBroker{Broker: rpcxtest.NewBroker(), failAt: 10, lost: lost}
+			require.Nil(t, broker.StartWith(broker))
+			defer broker.Stop()
+			_, cli, from := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker}, WithNonceManager())
+
+			var (
+				wg     sync.WaitGroup
+				mu     sync.Mutex
+				hashes []string
+				failed int
+			)
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					hash, err := sendTestTransfer(cli)
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						require.ErrorIs(t, err, ErrBrokenNetwork)
+						failed++
+						return
+					}
+					hashes = append(hashes, hash)
+				}()
+			}
+			wg.Wait()
+			require.Equal(t, 1, failed)
+
+			// the nonce of the broken send is neither reused nor skipped, so
+			// that no tx of the account gets stuck
+			hash, err := sendTestTransfer(cli)
+			require.Nil(t, err)
+			for _, hash := range append(hashes, hash) {
+				receipt, err := cli.WaitForReceipt(hash)
+				require.Nil(t, err)
+				require.True(t, receipt.IsSuccess())
+			}
+			expected := uint64(21)
+			if lost {
+				expected++
+			}
+			nonce, err := cli.GetPendingNonceByAccount(from.String())
+			require.Nil(t, err)
+			require.Equal(t, expected, nonce)
+		})
+	}
+}
+
+func TestChainClient_NonceManagerTimeout(t *testing.T) {
+	broker, cli, from := newBrokerClient(t, nil, WithNonceManager())
+	_, err := sendTestTransfer(cli)
+	require.Nil(t, err)
+
+	// a send which timed out may have reached BitXHub, so the nonce is reloaded
+	// instead of being reused or skipped
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.DeadlineExceeded, Lost: true, Times: 1})
+	_, err = sendTestTransfer(cli)
+	require.ErrorIs(t, err, ErrTimeout)
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+
+	// a tx rejected by BitXHub gives its nonce back
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.PermissionDenied, Times: 1})
+	_, err = sendTestTransfer(cli)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+
+	nonce, err := cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(5), nonce)
+}
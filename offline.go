@@ -0,0 +1,280 @@
+package rpcx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// TxEnvelopeVersion is the version of the envelope format written by this client.
+const TxEnvelopeVersion = 1
+
+// txEnvelopeMagic starts the binary encoding of a TxEnvelope.
+var txEnvelopeMagic = []byte("BXTX")
+
+// EnvelopeFormat is an encoding of TxEnvelope.
+type EnvelopeFormat int
+
+const (
+	// EnvelopeJSON is an indented JSON object, readable by whoever signs.
+	EnvelopeJSON EnvelopeFormat = iota
+	// EnvelopeBinary is the magic "BXTX", the version byte, the chain ID as
+	// big endian uint64 and the protobuf encoded transaction.
+	EnvelopeBinary
+	// EnvelopeBase64 is the binary encoding in standard base64, to be passed
+	// around as a single line.
+	EnvelopeBase64
+)
+
+// TxEnvelope carries a transaction between the machine sending it and the
+// machine holding the signing key. Tx is the protobuf encoded transaction, and
+// the other fields repeat what the signer should review. The chain ID is not
+// covered by the signature of BitXHub transactions, it is checked against the
+// chain the envelope is submitted to.
+type TxEnvelope struct {
+	Version  uint32 `json:"version"`
+	ChainID  uint64 `json:"chain_id"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Nonce    uint64 `json:"nonce"`
+	SignHash string `json:"sign_hash"`
+	Signed   bool   `json:"signed"`
+	Tx       []byte `json:"tx"`
+}
+
+// NewTxEnvelope wraps tx for chainID.
+func NewTxEnvelope(tx *pb.BxhTransaction, chainID uint64) (*TxEnvelope, error) {
+	if tx.From == nil || tx.To == nil {
+		return nil, fmt.Errorf("%w: from and to address can't be empty", ErrReconstruct)
+	}
+	data, err := tx.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &TxEnvelope{
+		Version:  TxEnvelopeVersion,
+		ChainID:  chainID,
+		From:     tx.From.String(),
+		To:       tx.To.String(),
+		Nonce:    tx.Nonce,
+		SignHash: tx.SignHash().String(),
+		Signed:   len(tx.Signature) != 0,
+		Tx:       data,
+	}, nil
+}
+
+// Transaction decodes the transaction, checking that the envelope fields
+// describe it.
+func (env *TxEnvelope) Transaction() (*pb.BxhTransaction, error) {
+	if env.Version != TxEnvelopeVersion {
+		return nil, fmt.Errorf("unsupported tx envelope version %d", env.Version)
+	}
+	tx := &pb.BxhTransaction{}
+	if err := tx.Unmarshal(env.Tx); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal enveloped tx: %s", ErrReconstruct, err.Error())
+	}
+	if tx.From == nil || tx.To == nil || tx.From.String() != env.From || tx.To.String() != env.To ||
+		tx.Nonce != env.Nonce || tx.SignHash().String() != env.SignHash || (len(tx.Signature) != 0) != env.Signed {
+		return nil, fmt.Errorf("%w: tx envelope doesn't match the enveloped tx", ErrReconstruct)
+	}
+	return tx, nil
+}
+
+// Sign signs the enveloped transaction with key, which must be the key of the
+// sender. It doesn't need a connection to BitXHub, so it can be done offline.
+func (env *TxEnvelope) Sign(key crypto.PrivateKey) error {
+	signer, err := NewKeySigner(key)
+	if err != nil {
+		return fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
+	}
+	return env.SignWith(signer)
+}
+
+// SignWith signs the enveloped transaction with signer, as Sign does with a key.
+func (env *TxEnvelope) SignWith(signer Signer) error {
+	tx, err := env.Transaction()
+	if err != nil {
+		return err
+	}
+	addr := signer.Address()
+	if addr.String() != tx.From.String() {
+		return fmt.Errorf("%w: signing key of %s for transaction from %s", ErrSignTx, addr, tx.From)
+	}
+	if err := signTx(tx, signer); err != nil {
+		return fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
+	}
+	signed, err := NewTxEnvelope(tx, env.ChainID)
+	if err != nil {
+		return err
+	}
+	*env = *signed
+	return nil
+}
+
+// Verify checks that the enveloped transaction is signed by its sender.
+func (env *TxEnvelope) Verify() error {
+	tx, err := env.Transaction()
+	if err != nil {
+		return err
+	}
+	if !env.Signed {
+		return fmt.Errorf("%w: transaction is not signed", ErrSignTx)
+	}
+	if err := tx.VerifySignature(); err != nil {
+		return fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
+	}
+	return nil
+}
+
+// Encode encodes the envelope in format.
+func (env *TxEnvelope) Encode(format EnvelopeFormat) ([]byte, error) {
+	switch format {
+	case EnvelopeJSON:
+		return json.MarshalIndent(env, "", "  ")
+	case EnvelopeBinary:
+		return env.encodeBinary()
+	case EnvelopeBase64:
+		data, err := env.encodeBinary()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(base64.StdEncoding.EncodeToString(data)), nil
+	default:
+		return nil, fmt.Errorf("unknown tx envelope format %d", format)
+	}
+}
+
+func (env *TxEnvelope) encodeBinary() ([]byte, error) {
+	if env.Version > 0xff {
+		return nil, fmt.Errorf("unsupported tx envelope version %d", env.Version)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(txEnvelopeMagic)+9+len(env.Tx)))
+	buf.Write(txEnvelopeMagic)
+	buf.WriteByte(byte(env.Version))
+	_ = binary.Write(buf, binary.BigEndian, env.ChainID)
+	buf.Write(env.Tx)
+	return buf.Bytes(), nil
+}
+
+// DecodeTxEnvelope decodes an envelope in any of the formats, which it tells
+// from the data itself, and checks it describes the enveloped transaction.
+func DecodeTxEnvelope(data []byte) (*TxEnvelope, error) {
+	data = bytes.TrimSpace(data)
+	var env *TxEnvelope
+	switch {
+	case bytes.HasPrefix(data, txEnvelopeMagic):
+		var err error
+		if env, err = decodeBinaryEnvelope(data); err != nil {
+			return nil, err
+		}
+	case bytes.HasPrefix(data, []byte("{")):
+		env = &TxEnvelope{}
+		if err := json.Unmarshal(data, env); err != nil {
+			return nil, fmt.Errorf("unmarshal tx envelope: %w", err)
+		}
+	default:
+		raw, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil || !bytes.HasPrefix(raw, txEnvelopeMagic) {
+			return nil, fmt.Errorf("unknown tx envelope format")
+		}
+		if env, err = decodeBinaryEnvelope(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := env.Transaction(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+func decodeBinaryEnvelope(data []byte) (*TxEnvelope, error) {
+	header := len(txEnvelopeMagic) + 9
+	if len(data) < header {
+		return nil, fmt.Errorf("tx envelope is truncated")
+	}
+	tx := &pb.BxhTransaction{}
+	if err := tx.Unmarshal(data[header:]); err != nil {
+		return nil, fmt.Errorf("%w: unmarshal enveloped tx: %s", ErrReconstruct, err.Error())
+	}
+	env, err := NewTxEnvelope(tx, binary.BigEndian.Uint64(data[len(txEnvelopeMagic)+1:header]))
+	if err != nil {
+		return nil, err
+	}
+	env.Version = uint32(data[len(txEnvelopeMagic)])
+	return env, nil
+}
+
+// ExportUnsignedTx wraps tx for signing offline, with its sender, nonce,
+// timestamp and the chain ID of BitXHub filled in. The sender and nonce are
+// taken from opts if set, otherwise the sender is tx.From and the nonce is the
+// pending nonce of the sender on BitXHub.
+func (cli *ChainClient) ExportUnsignedTx(tx *pb.BxhTransaction, opts *TransactOpts) (*TxEnvelope, error) {
+	return cli.ExportUnsignedTxCtx(context.Background(), tx, opts)
+}
+
+func (cli *ChainClient) ExportUnsignedTxCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (*TxEnvelope, error) {
+	unsigned := *tx
+	unsigned.Signature = nil
+	if opts != nil && opts.From != "" {
+		from := types.NewAddressByStr(opts.From)
+		if from == nil {
+			return nil, fmt.Errorf("%w: invalid from address %s", ErrReconstruct, opts.From)
+		}
+		unsigned.From = from
+	}
+	if unsigned.From == nil {
+		return nil, fmt.Errorf("%w: from address can't be empty", ErrReconstruct)
+	}
+
+	if opts != nil && opts.Nonce != 0 {
+		unsigned.Nonce = opts.Nonce
+	} else {
+		nonce, err := cli.GetPendingNonceByAccountCtx(ctx, unsigned.From.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve nonce for account %s: %w", unsigned.From, err)
+		}
+		unsigned.Nonce = nonce
+	}
+	if unsigned.Timestamp == 0 {
+		unsigned.Timestamp = time.Now().UnixNano()
+	}
+
+	chainID, err := cli.GetChainIDCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTxEnvelope(&unsigned, chainID)
+}
+
+// SubmitTxEnvelope verifies the signed transaction in env and sends it with
+// SendRawTransaction, if env is meant for the chain of cli.
+func (cli *ChainClient) SubmitTxEnvelope(env *TxEnvelope) (string, error) {
+	return cli.SubmitTxEnvelopeCtx(context.Background(), env)
+}
+
+func (cli *ChainClient) SubmitTxEnvelopeCtx(ctx context.Context, env *TxEnvelope) (string, error) {
+	if err := env.Verify(); err != nil {
+		return "", err
+	}
+	chainID, err := cli.GetChainIDCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+	if chainID != env.ChainID {
+		return "", fmt.Errorf("%w: tx envelope for chain %d is submitted to chain %d", ErrReconstruct, env.ChainID, chainID)
+	}
+	tx, err := env.Transaction()
+	if err != nil {
+		return "", err
+	}
+	return cli.SendRawTransactionCtx(ctx, tx)
+}
@@ -0,0 +1,90 @@
+package rpcx
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxEnvelope_OfflineSigning(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	ctx := context.Background()
+
+	// the key of the sender is only known to the offline signer
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	from, err := key.PublicKey().Address()
+	require.Nil(t, err)
+	broker.SetBalance(from, big.NewInt(10))
+	broker.SetNonce(from, 4)
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	tx, err := NewTxBuilder(from).Transfer(to, big.NewInt(10)).Build()
+	require.Nil(t, err)
+
+	env, err := cli.ExportUnsignedTxCtx(ctx, tx, nil)
+	require.Nil(t, err)
+	require.Equal(t, uint64(1356), env.ChainID)
+	require.Equal(t, uint64(5), env.Nonce)
+	require.False(t, env.Signed)
+	require.ErrorIs(t, env.Verify(), ErrSignTx)
+	_, err = cli.SubmitTxEnvelopeCtx(ctx, env)
+	require.ErrorIs(t, err, ErrSignTx)
+
+	for _, format := range []EnvelopeFormat{EnvelopeJSON, EnvelopeBinary, EnvelopeBase64} {
+		data, err := env.Encode(format)
+		require.Nil(t, err)
+
+		offline, err := DecodeTxEnvelope(data)
+		require.Nil(t, err)
+		require.Equal(t, env, offline)
+		other, err := asym.GenerateKeyPair(crypto.Secp256k1)
+		require.Nil(t, err)
+		require.ErrorIs(t, offline.Sign(other), ErrSignTx)
+		require.Nil(t, offline.Sign(key))
+		data, err = offline.Encode(format)
+		require.Nil(t, err)
+
+		signed, err := DecodeTxEnvelope(data)
+		require.Nil(t, err)
+		require.True(t, signed.Signed)
+		require.Equal(t, env.SignHash, signed.SignHash)
+		require.Nil(t, signed.Verify())
+	}
+
+	require.Nil(t, env.Sign(key))
+	hash, err := cli.SubmitTxEnvelopeCtx(ctx, env)
+	require.Nil(t, err)
+	receipt, err := cli.WaitForReceiptCtx(ctx, hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+
+	_, other, _ := newBrokerClient(t, []*rpcxtest.Broker{rpcxtest.NewBroker(rpcxtest.WithChainID(1357))})
+	_, err = other.SubmitTxEnvelopeCtx(ctx, env)
+	require.ErrorIs(t, err, ErrReconstruct)
+}
+
+func TestDecodeTxEnvelope_Tampered(t *testing.T) {
+	from := types.NewAddressByStr("0x0000000000000000000000000000000000000002")
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	tx, err := NewTxBuilder(from).Transfer(to, big.NewInt(10)).WithNonce(1).Build()
+	require.Nil(t, err)
+	env, err := NewTxEnvelope(tx, 1356)
+	require.Nil(t, err)
+
+	env.Nonce = 2
+	data, err := env.Encode(EnvelopeJSON)
+	require.Nil(t, err)
+	_, err = DecodeTxEnvelope(data)
+	require.ErrorIs(t, err, ErrReconstruct)
+
+	_, err = DecodeTxEnvelope([]byte("BXTX"))
+	require.NotNil(t, err)
+	_, err = DecodeTxEnvelope([]byte("not an envelope"))
+	require.NotNil(t, err)
+}
@@ -6,128 +6,283 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
-	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/Rican7/retry"
-	"github.com/Rican7/retry/strategy"
 	"github.com/meshplus/bitxhub-model/pb"
-	grpcpool "github.com/processout/grpc-go-pool"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 )
 
+// wait before getClient tries another node
+const getClientWait = 500 * time.Millisecond
+
 type grpcClient struct {
 	broker pb.ChainBrokerClient
-	conn   *grpcpool.ClientConn
+	conn   *grpc.ClientConn
+	node   *nodeHealth
+}
+
+// rpcError classifies the error of the call of method on the node of client.
+func (client *grpcClient) rpcError(method string, err error) error {
+	addr := ""
+	if client.node != nil {
+		addr = client.node.info.Addr
+	}
+	return newRPCError(method, addr, err)
+}
+
+// nodeChannels holds the long-lived grpc channels to a single node.
+// A grpc.ClientConn multiplexes concurrent calls over HTTP/2, so the channels
+// are shared by all callers instead of being checked out and returned.
+type nodeChannels struct {
+	node  *nodeHealth
+	mu    sync.Mutex
+	conns []*grpc.ClientConn
+	dials []*channelDial
+	next  uint64
+}
+
+// channelDial is a dial of a channel in flight, awaited by every caller which
+// needs the channel meanwhile.
+type channelDial struct {
+	done chan struct{}
+	conn *grpc.ClientConn
+	err  error
 }
 
 type ConnectionPool struct {
-	timeoutLimit  time.Duration // timeout limit config for dialing grpc
-	pool          *grpcpool.Pool
-	currentClient *grpcClient
-	logger        Logger
-	config        *config
-	clientCnt     uint64
+	timeoutLimit time.Duration // timeout limit config for dialing grpc
+	logger       Logger
+	config       *config
+	clientCnt    uint64
+	health       *healthTracker
+	metrics      Metrics
+	channels     map[*nodeHealth]*nodeChannels
+	closed       int32
+
+	// ctx bounds the dials of the pool, which outlive their callers
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
-// init a connection
+// init a connection pool, channels to nodes are dialed on first use
 func NewPool(config *config) (*ConnectionPool, error) {
 	pool := &ConnectionPool{
 		config:       config,
 		logger:       config.logger,
 		timeoutLimit: config.timeoutLimit,
+		health:       newHealthTracker(config),
+		metrics:      config.metrics,
+		channels:     make(map[*nodeHealth]*nodeChannels),
 	}
-	grpcPool, err := grpcpool.New(pool.newClient, 4, config.poolSize, 1*time.Hour)
-	if err != nil {
-		return nil, err
+	pool.ctx, pool.cancel = context.WithCancel(context.Background())
+	if pool.metrics == nil {
+		pool.metrics = nopMetrics{}
+	}
+	for _, node := range pool.health.nodes {
+		pool.channels[node] = &nodeChannels{
+			node:  node,
+			conns: make([]*grpc.ClientConn, config.poolSize),
+			dials: make([]*channelDial, config.poolSize),
+		}
 	}
 
-	pool.pool = grpcPool
 	return pool, nil
 }
 
 func (pool *ConnectionPool) Close() error {
-	pool.pool.Close()
+	if !atomic.CompareAndSwapInt32(&pool.closed, 0, 1) {
+		return nil
+	}
+	pool.cancel()
+	for _, channels := range pool.channels {
+		channels.mu.Lock()
+		for i, conn := range channels.conns {
+			if conn == nil {
+				continue
+			}
+			if err := conn.Close(); err != nil {
+				pool.logger.Errorf("close conn to %s err: %s", channels.node.info.Addr, err)
+			}
+			channels.conns[i] = nil
+		}
+		channels.mu.Unlock()
+	}
 	return nil
 }
 
-func (pool *ConnectionPool) getClient() (*grpcClient, error) {
-	//if pool.currentClient != nil && pool.currentClient.available() {
-	//	return pool.currentClient, nil
-	//}
-	conn, err := pool.pool.Get(context.Background())
+func (pool *ConnectionPool) IsClosed() bool {
+	return atomic.LoadInt32(&pool.closed) == 1
+}
+
+// NodeStatuses returns the health observed for every configured node.
+func (pool *ConnectionPool) NodeStatuses() []NodeStatus {
+	return pool.health.statuses()
+}
+
+// getClient returns a client over a channel to a healthy node, dialing the
+// channel if it has not been established yet. A failed node is followed by
+// another one until ctx is done.
+func (pool *ConnectionPool) getClient(ctx context.Context) (*grpcClient, error) {
+	attempts := 5 * len(pool.config.nodesInfo)
+	for attempt := 1; ; attempt++ {
+		client, err := pool.clientOf(ctx, pool.health.pick())
+		if err == nil || attempt >= attempts || pool.IsClosed() || ctx.Err() != nil {
+			return client, err
+		}
+
+		timer := time.NewTimer(getClientWait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, contextError(ctx, err)
+		}
+	}
+}
+
+// clientOf returns a client over a channel to the given node, whatever its health.
+func (pool *ConnectionPool) clientOf(ctx context.Context, node *nodeHealth) (*grpcClient, error) {
+	if pool.IsClosed() {
+		return nil, fmt.Errorf("connection pool is closed")
+	}
+	conn, err := pool.channels[node].get(ctx, pool)
 	if err != nil {
 		return nil, err
 	}
-	pool.currentClient = &grpcClient{
-		broker: pb.NewChainBrokerClient(conn.ClientConn),
+	return &grpcClient{
+		broker: pb.NewChainBrokerClient(conn),
 		conn:   conn,
-	}
-	return pool.currentClient, nil
+		node:   node,
+	}, nil
 }
 
-// get grpcClient will try to get idle grpcClient
-func (pool *ConnectionPool) newClient() (*grpc.ClientConn, error) {
-	var (
-		conn    *grpc.ClientConn
-		tlsErr  error
-		connErr error
-	)
-	if connErr = retry.Retry(func(attempt uint) error {
-		randGenerator := rand.New(rand.NewSource(time.Now().UnixNano()))
-		randomIndex := randGenerator.Intn(len(pool.config.nodesInfo))
-		nodeInfo := pool.config.nodesInfo[randomIndex]
-		// try to build a connect or reconnect
-		opts := []grpc.DialOption{grpc.WithBlock(), grpc.WithTimeout(pool.timeoutLimit)}
-		// if EnableTLS is set, then setup connection with ca cert
-		if nodeInfo.EnableTLS {
-			var certPathByte []byte
-			certPathByte, tlsErr = ioutil.ReadFile(nodeInfo.CertPath)
-			if tlsErr != nil {
-				return nil
-			}
-			cp := x509.NewCertPool()
-			if !cp.AppendCertsFromPEM(certPathByte) {
-				tlsErr = fmt.Errorf("credentials: failed to append certificates")
-				return nil
-			}
-			cert, err := tls.LoadX509KeyPair(nodeInfo.AccessCert, nodeInfo.AccessKey)
-			if err != nil {
-				pool.logger.Debugf("Creat tls credentials from %s for client %s", nodeInfo.CertPath, nodeInfo.Addr)
-				tlsErr = fmt.Errorf("%w: tls config is not right", ErrBrokenNetwork)
-				return nil
-			}
-			creds := credentials.NewTLS(&tls.Config{
-				Certificates: []tls.Certificate{cert}, ServerName: nodeInfo.CommonName, RootCAs: cp})
+// get picks one of the node channels in turn. A missing or shut down channel
+// is dialed again outside of the lock, so that the callers of the other
+// channels don't wait for the dial, and a channel in transient failure fails
+// the call so that the caller can try another node.
+func (channels *nodeChannels) get(ctx context.Context, pool *ConnectionPool) (*grpc.ClientConn, error) {
+	index := (atomic.AddUint64(&channels.next, 1) - 1) % uint64(len(channels.conns))
 
-			opts = append(opts, grpc.WithTransportCredentials(creds))
-		} else {
-			opts = append(opts, grpc.WithInsecure())
-		}
-		conn, connErr = grpc.Dial(nodeInfo.Addr, opts...)
-		if connErr != nil {
-			pool.logger.Infof("Dial with addr: %s fail", nodeInfo.Addr)
-			return fmt.Errorf("%w: dial node %s failed", ErrBrokenNetwork, nodeInfo.Addr)
+	channels.mu.Lock()
+	if conn := channels.conns[index]; conn != nil {
+		switch conn.GetState() {
+		case connectivity.Shutdown:
+			channels.conns[index] = nil
+		case connectivity.TransientFailure:
+			// watch reports the failure to the health of the node
+			channels.mu.Unlock()
+			return nil, fmt.Errorf("%w: node %s is unavailable", ErrBrokenNetwork, channels.node.info.Addr)
+		default:
+			channels.mu.Unlock()
+			return conn, nil
 		}
-		pool.logger.Debugf("Establish connection with bitxhub %s successfully, pool is %d pool conn cnt is %d", nodeInfo.Addr, pool.pool.Available(), atomic.AddUint64(&pool.clientCnt, 1))
-		return nil
-	}, strategy.Wait(500*time.Millisecond), strategy.Limit(uint(5*len(pool.config.nodesInfo)))); connErr != nil {
-		return nil, connErr
 	}
+	dial := channels.dials[index]
+	if dial == nil {
+		dial = &channelDial{done: make(chan struct{})}
+		channels.dials[index] = dial
+		go channels.dial(pool, index, dial)
+	}
+	channels.mu.Unlock()
 
-	if tlsErr != nil {
-		return nil, tlsErr
+	select {
+	case <-dial.done:
+		return dial.conn, dial.err
+	case <-ctx.Done():
+		return nil, contextError(ctx, fmt.Errorf("dial node %s", channels.node.info.Addr))
 	}
+}
 
-	return conn, nil
+// dial dials the channel of index with the context of the pool, as it is
+// shared by the callers waiting for it.
+func (channels *nodeChannels) dial(pool *ConnectionPool, index uint64, dial *channelDial) {
+	conn, err := pool.dial(pool.ctx, channels.node)
+
+	channels.mu.Lock()
+	defer channels.mu.Unlock()
+	channels.dials[index] = nil
+	if err == nil && pool.IsClosed() {
+		_ = conn.Close()
+		conn, err = nil, fmt.Errorf("connection pool is closed")
+	}
+	if err == nil {
+		channels.conns[index] = conn
+		pool.metrics.ConnectionOpened(channels.node.info.Addr)
+		go pool.watch(channels.node, conn)
+	}
+	dial.conn, dial.err = conn, err
+	close(dial.done)
+}
+
+// watch follows the connectivity state of a channel and feeds it into node health
+// until the channel is shut down.
+func (pool *ConnectionPool) watch(node *nodeHealth, conn *grpc.ClientConn) {
+	state := conn.GetState()
+	for conn.WaitForStateChange(context.Background(), state) {
+		state = conn.GetState()
+		switch state {
+		case connectivity.TransientFailure:
+			pool.logger.Debugf("Connection with bitxhub %s is in transient failure", node.info.Addr)
+			pool.health.reportFailure(node)
+		case connectivity.Shutdown:
+			pool.metrics.ConnectionClosed(node.info.Addr)
+			return
+		}
+	}
 }
 
-/*func (grpcCli *grpcClient) available() bool {
-	if grpcCli.conn.ClientConn == nil {
-		return false
+// dial establishes a new channel to the node, waiting for it until ctx is done
+// or the timeout limit of the pool is reached.
+func (pool *ConnectionPool) dial(ctx context.Context, node *nodeHealth) (*grpc.ClientConn, error) {
+	nodeInfo := node.info
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithKeepaliveParams(pool.config.keepalive),
+		grpc.WithChainUnaryInterceptor(append([]grpc.UnaryClientInterceptor{pool.health.unaryInterceptor(node)}, pool.config.unaryInterceptors...)...),
+		grpc.WithChainStreamInterceptor(append([]grpc.StreamClientInterceptor{pool.health.streamInterceptor(node)}, pool.config.streamInterceptors...)...),
+	}
+	// if EnableTLS is set, then setup connection with ca cert
+	if nodeInfo.EnableTLS {
+		certPathByte, err := ioutil.ReadFile(nodeInfo.CertPath)
+		if err != nil {
+			return nil, err
+		}
+		cp := x509.NewCertPool()
+		if !cp.AppendCertsFromPEM(certPathByte) {
+			return nil, fmt.Errorf("credentials: failed to append certificates")
+		}
+		cert, err := tls.LoadX509KeyPair(nodeInfo.AccessCert, nodeInfo.AccessKey)
+		if err != nil {
+			pool.logger.Debugf("Creat tls credentials from %s for client %s", nodeInfo.CertPath, nodeInfo.Addr)
+			return nil, fmt.Errorf("%w: tls config is not right", ErrBrokenNetwork)
+		}
+		creds := credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert}, ServerName: nodeInfo.CommonName, RootCAs: cp})
+
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
 	}
-	s := grpcCli.conn.ClientConn.GetState()
-	return s == connectivity.Idle || s == connectivity.Ready
-}*/
+
+	start := time.Now()
+	dialCtx, cancel := context.WithTimeout(ctx, pool.timeoutLimit)
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, nodeInfo.Addr, opts...)
+	if err != nil {
+		// a dial given up as ctx is done tells nothing about the node
+		if ctx.Err() != nil {
+			return nil, contextError(ctx, err)
+		}
+		pool.health.reportFailure(node)
+		pool.metrics.DialFailed(nodeInfo.Addr)
+		pool.logger.Infof("Dial with addr: %s fail", nodeInfo.Addr)
+		return nil, fmt.Errorf("%w: dial node %s failed", ErrBrokenNetwork, nodeInfo.Addr)
+	}
+	pool.health.reportSuccess(node, time.Since(start))
+	pool.logger.Debugf("Establish connection with bitxhub %s successfully, pool conn cnt is %d", nodeInfo.Addr, atomic.AddUint64(&pool.clientCnt, 1))
+	return conn, nil
+}
@@ -0,0 +1,178 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func unusedAddr(t testing.TB) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	addr := lis.Addr().String()
+	require.Nil(t, lis.Close())
+	return addr
+}
+
+func TestConnectionPool_Failover(t *testing.T) {
+	broker := rpcxtest.NewBroker()
+	require.Nil(t, broker.Start())
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker},
+		WithNodesInfo(&NodeInfo{Addr: unusedAddr(t)}, &NodeInfo{Addr: broker.Addr()}),
+		WithTimeoutLimit(200*time.Millisecond),
+		WithNodeSelector(PrimarySelector()),
+		WithNodeEjection(1, time.Minute, time.Minute),
+	)
+
+	meta, err := cli.GetChainMeta()
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), meta.Height)
+
+	statuses := cli.pool.NodeStatuses()
+	require.False(t, statuses[0].Healthy)
+	require.True(t, statuses[1].Healthy)
+
+	// the dead primary is ejected, so the live node is used straight away
+	for i := 0; i < 10; i++ {
+		_, err := cli.GetChainMeta()
+		require.Nil(t, err)
+	}
+	require.Equal(t, uint64(1), cli.pool.NodeStatuses()[0].ConsecutiveFailures)
+}
+
+func TestConnectionPool_ContextDone(t *testing.T) {
+	privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+
+	cli, err := NewWithNoGlobalPool(
+		WithNodesInfo(&NodeInfo{Addr: unusedAddr(t)}),
+		WithLogger(logrus.New()),
+		WithPrivateKey(privKey),
+		WithTimeoutLimit(10*time.Second),
+	)
+	require.Nil(t, err)
+	defer cli.Stop()
+
+	// the caller doesn't wait for the node longer than its deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = cli.GetChainMetaCtx(ctx)
+	require.ErrorIs(t, err, ErrTimeout)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, uint64(0), cli.pool.NodeStatuses()[0].ConsecutiveFailures)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = cli.GetChainMetaCtx(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestConnectionPool_SharedDial(t *testing.T) {
+	addr := unusedAddr(t)
+	metrics := NewPrometheusMetrics("")
+	pool, err := NewPool(&config{
+		nodesInfo:    []*NodeInfo{{Addr: addr}},
+		logger:       logrus.New(),
+		timeoutLimit: 200 * time.Millisecond,
+		poolSize:     1,
+		nodeSelector: RandomSelector(),
+		maxFailures:  defaultMaxFailures,
+		metrics:      metrics,
+	})
+	require.Nil(t, err)
+	defer pool.Close()
+
+	// the callers of a channel wait for the same dial instead of one another
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := pool.clientOf(context.Background(), pool.health.nodes[0])
+			require.ErrorIs(t, err, ErrBrokenNetwork)
+		}()
+	}
+	wg.Wait()
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.dialFailures.WithLabelValues(addr)))
+	require.Equal(t, uint64(1), pool.NodeStatuses()[0].ConsecutiveFailures)
+}
+
+func TestConnectionPool_InvalidSize(t *testing.T) {
+	privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+
+	_, err = NewWithNoGlobalPool(
+		WithNodesInfo(&NodeInfo{Addr: unusedAddr(t)}),
+		WithPrivateKey(privKey),
+		WithPoolSize(-1),
+	)
+	require.NotNil(t, err)
+}
+
+func TestConnectionPool_Close(t *testing.T) {
+	_, cli, _ := newBrokerClient(t, nil)
+
+	_, err := cli.GetChainMeta()
+	require.Nil(t, err)
+
+	pool := cli.pool
+	require.Nil(t, cli.Stop())
+	require.True(t, pool.IsClosed())
+	_, err = pool.getClient(context.Background())
+	require.NotNil(t, err)
+}
+
+func BenchmarkChainClient_SendTransaction(b *testing.B) {
+	broker := rpcxtest.NewBroker()
+	require.Nil(b, broker.Start())
+	defer broker.Stop()
+	logger := logrus.New()
+	logger.SetLevel(logrus.WarnLevel)
+
+	for _, size := range []int{1, 4} {
+		b.Run(fmt.Sprintf("channels-%d", size), func(b *testing.B) {
+			_, cli, _ := newBrokerClient(b, []*rpcxtest.Broker{broker}, WithLogger(logger), WithPoolSize(size))
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(p *testing.PB) {
+				// every goroutine sends from an account of its own, as the
+				// broker only accepts the nonces of an account in order
+				privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
+				require.Nil(b, err)
+				from, err := privKey.PublicKey().Address()
+				require.Nil(b, err)
+				for nonce := uint64(1); p.Next(); nonce++ {
+					tx := &pb.BxhTransaction{
+						From:      from,
+						To:        from,
+						Timestamp: time.Now().UnixNano(),
+					}
+					if _, err := cli.SendTransaction(tx, &TransactOpts{
+						From:    from.String(),
+						Nonce:   nonce,
+						PrivKey: privKey,
+					}); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
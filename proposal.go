@@ -0,0 +1,159 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const defaultProposalPollInterval = time.Second
+
+type ProposalStatus string
+
+const (
+	ProposalProposed ProposalStatus = "proposed"
+	ProposalApproved ProposalStatus = "approve"
+	ProposalRejected ProposalStatus = "reject"
+	ProposalPaused   ProposalStatus = "paused"
+)
+
+// Closed reports whether the proposal has reached its outcome.
+func (status ProposalStatus) Closed() bool {
+	return status == ProposalApproved || status == ProposalRejected
+}
+
+type ProposalType string
+
+const (
+	AppchainMgrProposal         ProposalType = "AppchainMgr"
+	RuleMgrProposal             ProposalType = "RuleMgr"
+	NodeMgrProposal             ProposalType = "NodeMgr"
+	ServiceMgrProposal          ProposalType = "ServiceMgr"
+	RoleMgrProposal             ProposalType = "RoleMgr"
+	ProposalStrategyMgrProposal ProposalType = "ProposalStrategyMgr"
+	DappMgrProposal             ProposalType = "DappMgr"
+)
+
+type Ballot struct {
+	VoterAddr string `json:"voter_addr"`
+	Approve   string `json:"approve"`
+	Num       uint64 `json:"num"`
+	Reason    string `json:"reason"`
+	VoteTime  int64  `json:"vote_time"`
+}
+
+// Proposal is a governance proposal as stored by the governance contract.
+type Proposal struct {
+	ID                     string            `json:"id"`
+	Des                    string            `json:"des"`
+	Typ                    ProposalType      `json:"typ"`
+	Status                 ProposalStatus    `json:"status"`
+	ObjID                  string            `json:"obj_id"`
+	BallotMap              map[string]Ballot `json:"ballot_map"`
+	ApproveNum             uint64            `json:"approve_num"`
+	AgainstNum             uint64            `json:"against_num"`
+	InitialElectorateNum   uint64            `json:"initial_electorate_num"`
+	AvaliableElectorateNum uint64            `json:"avaliable_electorate_num"`
+	ThresholdElectorateNum uint64            `json:"threshold_electorate_num"`
+	EventType              string            `json:"event_type"`
+	EndReason              string            `json:"end_reason"`
+	SubmitReason           string            `json:"submit_reason"`
+	WithdrawReason         string            `json:"withdraw_reason"`
+	Extra                  []byte            `json:"extra"`
+	CreateTime             int64             `json:"create_time"`
+}
+
+// ProposalFilter selects proposals to list. Empty fields match any proposal,
+// but at least one of them must be set.
+type ProposalFilter struct {
+	Type      ProposalType
+	Status    ProposalStatus
+	Initiator string
+}
+
+func (filter *ProposalFilter) match(proposal *Proposal) bool {
+	return (filter.Type == "" || proposal.Typ == filter.Type) &&
+		(filter.Status == "" || proposal.Status == filter.Status)
+}
+
+type proposalClient struct {
+	cli *ChainClient
+}
+
+var _ Proposals = (*proposalClient)(nil)
+
+// Proposals returns the client of the BitXHub governance proposals.
+func (cli *ChainClient) Proposals() Proposals {
+	return &proposalClient{cli: cli}
+}
+
+func (p *proposalClient) Get(ctx context.Context, id string) (*Proposal, error) {
+	proposal := &Proposal{}
+	if err := p.cli.queryBVM(ctx, constant.GovernanceContractAddr, "GetProposal", proposal, String(id)); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// List returns the proposals matching filter. The initiator is matched by the
+// governance contract, type and status are matched by the client if both are set.
+func (p *proposalClient) List(ctx context.Context, filter ProposalFilter) ([]*Proposal, error) {
+	var (
+		proposals []*Proposal
+		err       error
+	)
+	switch {
+	case filter.Initiator != "":
+		err = p.cli.queryBVM(ctx, constant.GovernanceContractAddr, "GetProposalsByFrom", &proposals, String(filter.Initiator))
+	case filter.Status != "":
+		err = p.cli.queryBVM(ctx, constant.GovernanceContractAddr, "GetProposalsByStatus", &proposals, String(string(filter.Status)))
+	case filter.Type != "":
+		err = p.cli.queryBVM(ctx, constant.GovernanceContractAddr, "GetProposalsByTyp", &proposals, String(string(filter.Type)))
+	default:
+		return nil, fmt.Errorf("proposal filter is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matched := proposals[:0]
+	for _, proposal := range proposals {
+		if filter.match(proposal) {
+			matched = append(matched, proposal)
+		}
+	}
+	return matched, nil
+}
+
+// Vote approves or rejects the proposal on behalf of the client's account.
+func (p *proposalClient) Vote(ctx context.Context, id string, approve bool, reason string, opts *TransactOpts) (*pb.Receipt, error) {
+	ballot := string(ProposalRejected)
+	if approve {
+		ballot = string(ProposalApproved)
+	}
+	return p.cli.invokeBVM(ctx, constant.GovernanceContractAddr, "Vote", opts, String(id), String(ballot), String(reason))
+}
+
+// Withdraw withdraws a proposal submitted by the client's account.
+func (p *proposalClient) Withdraw(ctx context.Context, id, reason string, opts *TransactOpts) (*pb.Receipt, error) {
+	return p.cli.invokeBVM(ctx, constant.GovernanceContractAddr, "WithdrawProposal", opts, String(id), String(reason))
+}
+
+// WaitProposal blocks until the proposal is approved or rejected and returns it.
+// The proposal is checked again on every new block, and periodically in case
+// blocks can't be subscribed.
+func (p *proposalClient) WaitProposal(ctx context.Context, id string) (*Proposal, error) {
+	var proposal *Proposal
+	err := p.cli.waitOnBlocks(ctx, "proposal "+id, defaultProposalPollInterval, func(ctx context.Context) (bool, error) {
+		var err error
+		proposal, err = p.Get(ctx, id)
+		return err == nil && proposal.Status.Closed(), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
@@ -0,0 +1,135 @@
+package rpcx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// proposalContract serves the governance contract of a single proposal,
+// approving the proposal once enough approve votes are committed.
+type proposalContract struct {
+	mu       sync.Mutex
+	proposal *Proposal
+}
+
+// newProposalClient connects a client to a broker serving a proposalContract.
+func newProposalClient(t *testing.T) (*rpcxtest.Broker, *ChainClient) {
+	contract := &proposalContract{
+		proposal: &Proposal{
+			ID:                     "0x123-1",
+			Typ:                    AppchainMgrProposal,
+			Status:                 ProposalProposed,
+			ThresholdElectorateNum: 2,
+		},
+	}
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.HandleInvoke(constant.GovernanceContractAddr.Address(), contract.invoke)
+	return broker, cli
+}
+
+func (c *proposalContract) invoke(_ *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ret interface{}
+	switch method {
+	case "Vote":
+		if string(args[1].Value) == string(ProposalApproved) {
+			c.proposal.ApproveNum++
+		} else {
+			c.proposal.AgainstNum++
+		}
+		if c.proposal.ApproveNum >= c.proposal.ThresholdElectorateNum {
+			c.proposal.Status = ProposalApproved
+		}
+		return nil, nil
+	case "WithdrawProposal":
+		c.proposal.Status = ProposalRejected
+		c.proposal.WithdrawReason = string(args[1].Value)
+		return nil, nil
+	case "GetProposal":
+		if string(args[0].Value) != c.proposal.ID {
+			return nil, errors.New("proposal does not exist")
+		}
+		ret = c.proposal
+	case "GetProposalsByStatus":
+		proposals := []*Proposal{}
+		if string(args[0].Value) == string(c.proposal.Status) {
+			proposals = append(proposals, c.proposal)
+		}
+		ret = proposals
+	default:
+		return nil, fmt.Errorf("no such method %s", method)
+	}
+	return json.Marshal(ret)
+}
+
+func TestProposals_WaitProposal(t *testing.T) {
+	_, cli := newProposalClient(t)
+	proposals := cli.Proposals()
+	ctx := context.Background()
+
+	proposal, err := proposals.Get(ctx, "0x123-1")
+	require.Nil(t, err)
+	require.Equal(t, ProposalProposed, proposal.Status)
+	_, err = proposals.Get(ctx, "0x123-2")
+	require.NotNil(t, err)
+
+	list, err := proposals.List(ctx, ProposalFilter{Status: ProposalProposed, Type: AppchainMgrProposal})
+	require.Nil(t, err)
+	require.Len(t, list, 1)
+	list, err = proposals.List(ctx, ProposalFilter{Status: ProposalProposed, Type: RuleMgrProposal})
+	require.Nil(t, err)
+	require.Len(t, list, 0)
+	_, err = proposals.List(ctx, ProposalFilter{})
+	require.NotNil(t, err)
+
+	done := make(chan *Proposal)
+	go func() {
+		proposal, err := proposals.WaitProposal(ctx, "0x123-1")
+		require.Nil(t, err)
+		done <- proposal
+	}()
+
+	for i := 0; i < 2; i++ {
+		_, err := proposals.Vote(ctx, "0x123-1", true, "reason", nil)
+		require.Nil(t, err)
+	}
+
+	select {
+	case proposal := <-done:
+		require.Equal(t, ProposalApproved, proposal.Status)
+		require.Equal(t, uint64(2), proposal.ApproveNum)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proposal is not approved")
+	}
+}
+
+func TestProposals_WaitProposalTimeout(t *testing.T) {
+	broker, cli := newProposalClient(t)
+	broker.InjectFault("Subscribe", rpcxtest.Fault{Code: codes.Unimplemented})
+	proposals := cli.Proposals()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := proposals.WaitProposal(ctx, "0x123-1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = proposals.Withdraw(context.Background(), "0x123-1", "mistake", nil)
+	require.Nil(t, err)
+	proposal, err := proposals.WaitProposal(context.Background(), "0x123-1")
+	require.Nil(t, err)
+	require.Equal(t, ProposalRejected, proposal.Status)
+	require.Equal(t, "mistake", proposal.WithdrawReason)
+}
@@ -0,0 +1,202 @@
+package rpcx
+
+import (
+	"context"
+
+	"github.com/meshplus/bitxhub-model/constant"
+)
+
+// GovernanceStatus is the status of an object managed by BitXHub governance.
+type GovernanceStatus string
+
+const (
+	GovernanceRegisting   GovernanceStatus = "registing"
+	GovernanceAvailable   GovernanceStatus = "available"
+	GovernanceUnavailable GovernanceStatus = "unavailable"
+	GovernanceUpdating    GovernanceStatus = "updating"
+	GovernanceFrozen      GovernanceStatus = "frozen"
+	GovernanceFreezing    GovernanceStatus = "freezing"
+	GovernanceActivating  GovernanceStatus = "activating"
+	GovernanceLogouting   GovernanceStatus = "logouting"
+	GovernanceForbidden   GovernanceStatus = "forbidden"
+)
+
+type Appchain struct {
+	ID        string           `json:"id"`
+	ChainName string           `json:"chain_name"`
+	ChainType string           `json:"chain_type"`
+	TrustRoot []byte           `json:"trust_root"`
+	Broker    []byte           `json:"broker"`
+	Desc      string           `json:"desc"`
+	Version   uint64           `json:"version"`
+	Status    GovernanceStatus `json:"status"`
+}
+
+type Service struct {
+	ChainID    string              `json:"chain_id"`
+	ServiceID  string              `json:"service_id"`
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Intro      string              `json:"intro"`
+	Ordered    bool                `json:"ordered"`
+	Permission map[string]struct{} `json:"permission"`
+	Details    string              `json:"details"`
+	Score      float64             `json:"score"`
+	Status     GovernanceStatus    `json:"status"`
+}
+
+type Node struct {
+	Account     string              `json:"account"`
+	NodeType    string              `json:"node_type"`
+	Pid         string              `json:"pid"`
+	VPNodeID    uint64              `json:"vp_node_id"`
+	Name        string              `json:"name"`
+	Permissions map[string]struct{} `json:"permissions"`
+	Status      GovernanceStatus    `json:"status"`
+}
+
+type Rule struct {
+	Address string           `json:"address"`
+	RuleURL string           `json:"rule_url"`
+	ChainID string           `json:"chain_id"`
+	Master  bool             `json:"master"`
+	Status  GovernanceStatus `json:"status"`
+}
+
+type Role struct {
+	ID         string           `json:"id"`
+	RoleType   string           `json:"role_type"`
+	Weight     uint64           `json:"weight"`
+	NodePid    string           `json:"pid"`
+	AppchainID string           `json:"appchain_id"`
+	Status     GovernanceStatus `json:"status"`
+}
+
+// GetAppchain returns the appchain registered with the given ID. An error
+// wrapping ErrNotFound is returned if there is no such appchain, and likewise
+// for the other typed queries of BitXHub's built-in contracts.
+func (cli *ChainClient) GetAppchain(id string) (*Appchain, error) {
+	return cli.GetAppchainCtx(context.Background(), id)
+}
+
+func (cli *ChainClient) GetAppchainCtx(ctx context.Context, id string) (*Appchain, error) {
+	appchain := &Appchain{}
+	if err := cli.queryBVM(ctx, constant.AppchainMgrContractAddr, "GetAppchain", appchain, String(id)); err != nil {
+		return nil, err
+	}
+	return appchain, nil
+}
+
+func (cli *ChainClient) ListAppchains() ([]*Appchain, error) {
+	return cli.ListAppchainsCtx(context.Background())
+}
+
+func (cli *ChainClient) ListAppchainsCtx(ctx context.Context) ([]*Appchain, error) {
+	var appchains []*Appchain
+	if err := cli.queryBVM(ctx, constant.AppchainMgrContractAddr, "Appchains", &appchains); err != nil {
+		return nil, err
+	}
+	return appchains, nil
+}
+
+// GetService returns the service with the given full ID, as "chainID:serviceID".
+func (cli *ChainClient) GetService(chainServiceID string) (*Service, error) {
+	return cli.GetServiceCtx(context.Background(), chainServiceID)
+}
+
+func (cli *ChainClient) GetServiceCtx(ctx context.Context, chainServiceID string) (*Service, error) {
+	service := &Service{}
+	if err := cli.queryBVM(ctx, constant.ServiceMgrContractAddr, "GetServiceInfo", service, String(chainServiceID)); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// ListServices returns the services registered by an appchain.
+func (cli *ChainClient) ListServices(chainID string) ([]*Service, error) {
+	return cli.ListServicesCtx(context.Background(), chainID)
+}
+
+func (cli *ChainClient) ListServicesCtx(ctx context.Context, chainID string) ([]*Service, error) {
+	var services []*Service
+	if err := cli.queryBVM(ctx, constant.ServiceMgrContractAddr, "GetServicesByAppchainID", &services, String(chainID)); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// GetNode returns the node registered with the given account.
+func (cli *ChainClient) GetNode(account string) (*Node, error) {
+	return cli.GetNodeCtx(context.Background(), account)
+}
+
+func (cli *ChainClient) GetNodeCtx(ctx context.Context, account string) (*Node, error) {
+	node := &Node{}
+	if err := cli.queryBVM(ctx, constant.NodeManagerContractAddr, "GetNode", node, String(account)); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (cli *ChainClient) ListNodes() ([]*Node, error) {
+	return cli.ListNodesCtx(context.Background())
+}
+
+func (cli *ChainClient) ListNodesCtx(ctx context.Context) ([]*Node, error) {
+	var nodes []*Node
+	if err := cli.queryBVM(ctx, constant.NodeManagerContractAddr, "Nodes", &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetRule returns the validation rule deployed at ruleAddr for an appchain.
+func (cli *ChainClient) GetRule(chainID, ruleAddr string) (*Rule, error) {
+	return cli.GetRuleCtx(context.Background(), chainID, ruleAddr)
+}
+
+func (cli *ChainClient) GetRuleCtx(ctx context.Context, chainID, ruleAddr string) (*Rule, error) {
+	rule := &Rule{}
+	if err := cli.queryBVM(ctx, constant.RuleManagerContractAddr, "GetRuleByAddr", rule, String(chainID), String(ruleAddr)); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// ListRules returns the validation rules registered for an appchain.
+func (cli *ChainClient) ListRules(chainID string) ([]*Rule, error) {
+	return cli.ListRulesCtx(context.Background(), chainID)
+}
+
+func (cli *ChainClient) ListRulesCtx(ctx context.Context, chainID string) ([]*Rule, error) {
+	var rules []*Rule
+	if err := cli.queryBVM(ctx, constant.RuleManagerContractAddr, "Rules", &rules, String(chainID)); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (cli *ChainClient) GetRole(id string) (*Role, error) {
+	return cli.GetRoleCtx(context.Background(), id)
+}
+
+func (cli *ChainClient) GetRoleCtx(ctx context.Context, id string) (*Role, error) {
+	role := &Role{}
+	if err := cli.queryBVM(ctx, constant.RoleContractAddr, "GetRoleById", role, String(id)); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// GetInterchain returns the interchain counters of an appchain or service.
+func (cli *ChainClient) GetInterchain(id string) (*Interchain, error) {
+	return cli.GetInterchainCtx(context.Background(), id)
+}
+
+func (cli *ChainClient) GetInterchainCtx(ctx context.Context, id string) (*Interchain, error) {
+	interchain := &Interchain{}
+	if err := cli.queryBVM(ctx, constant.InterchainContractAddr, "GetInterchain", interchain, String(id)); err != nil {
+		return nil, err
+	}
+	return interchain, nil
+}
@@ -0,0 +1,82 @@
+package rpcx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+)
+
+// handleViews lets broker answer views by the called contract, method and
+// args joined with ",". Views of those contracts it has no answer for fail with
+// "this object does not exist".
+func handleViews(broker *rpcxtest.Broker, views map[string]string) {
+	for key := range views {
+		contract := strings.SplitN(key, ",", 2)[0]
+		broker.HandleInvoke(types.NewAddressByStr(contract), func(tx *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error) {
+			key := []string{tx.To.String(), method}
+			for _, arg := range args {
+				key = append(key, string(arg.Value))
+			}
+			ret, ok := views[strings.Join(key, ",")]
+			if !ok {
+				return nil, errors.New("this object does not exist")
+			}
+			return []byte(ret), nil
+		})
+	}
+}
+
+func TestChainClient_TypedQueries(t *testing.T) {
+	appchainMgr := constant.AppchainMgrContractAddr.Address().String()
+	serviceMgr := constant.ServiceMgrContractAddr.Address().String()
+	interchainMgr := constant.InterchainContractAddr.Address().String()
+	broker, cli, _ := newBrokerClient(t, nil)
+	handleViews(broker, map[string]string{
+		appchainMgr + ",GetAppchain,appchain1":             `{"id":"appchain1","chain_type":"ETH","broker":"MHhicm9rZXI=","status":"available"}`,
+		appchainMgr + ",Appchains":                         `[{"id":"appchain1"},{"id":"appchain2"}]`,
+		serviceMgr + ",GetServicesByAppchainID,appchain1":  `[{"chain_id":"appchain1","service_id":"s1","ordered":true,"permission":{"appchain2:s2":{}}}]`,
+		serviceMgr + ",GetServicesByAppchainID,appchain2":  `null`,
+		interchainMgr + ",GetInterchain,1356:appchain1:s1": `{"id":"1356:appchain1:s1","interchain_counter":{"1356:appchain2:s2":3}}`,
+		appchainMgr + ",GetAppchain,broken":                `{"id":`,
+	})
+
+	appchain, err := cli.GetAppchain("appchain1")
+	require.Nil(t, err)
+	require.Equal(t, "ETH", appchain.ChainType)
+	require.Equal(t, []byte("0xbroker"), appchain.Broker)
+	require.Equal(t, GovernanceAvailable, appchain.Status)
+
+	appchains, err := cli.ListAppchains()
+	require.Nil(t, err)
+	require.Len(t, appchains, 2)
+
+	services, err := cli.ListServices("appchain1")
+	require.Nil(t, err)
+	require.Len(t, services, 1)
+	require.True(t, services[0].Ordered)
+	require.Contains(t, services[0].Permission, "appchain2:s2")
+	services, err = cli.ListServices("appchain2")
+	require.Nil(t, err)
+	require.Empty(t, services)
+
+	interchain, err := cli.GetInterchain("1356:appchain1:s1")
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), interchain.InterchainCounter["1356:appchain2:s2"])
+
+	_, err = cli.GetAppchain("appchain3")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = cli.GetNode("0x123")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = cli.GetRule("appchain1", HappyRuleAddr)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = cli.GetAppchain("broken")
+	require.NotNil(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,108 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const (
+	WaitReceiptTimeout         = 30 * time.Second
+	defaultReceiptPollInterval = 500 * time.Millisecond
+)
+
+// WaitForReceipt waits until the transaction is committed and returns its receipt.
+// It follows new blocks through a block subscription and only fetches the receipt
+// once a block containing the transaction arrives. If the subscription can't be
+// established or breaks, it falls back to polling GetReceipt.
+// Every query of the receipt is bounded by GetReceiptTimeout, and the wait ends
+// early if BitXHub rejects a query for another reason than the receipt not
+// being found yet. WaitForReceipt waits at most WaitReceiptTimeout, like
+// WaitForReceiptCtx if ctx carries no deadline.
+func (cli *ChainClient) WaitForReceipt(hash string) (*pb.Receipt, error) {
+	return cli.WaitForReceiptCtx(context.Background(), hash)
+}
+
+func (cli *ChainClient) WaitForReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
+	start := time.Now()
+	receipt, err := cli.waitForReceipt(ctx, hash)
+	cli.metrics.ObserveReceiptWait(time.Since(start), err)
+	return receipt, err
+}
+
+func (cli *ChainClient) waitForReceipt(ctx context.Context, hash string) (*pb.Receipt, error) {
+	ctx, cancel := withDefaultTimeout(ctx, WaitReceiptTimeout)
+	defer cancel()
+
+	blocks, err := cli.Subscribe(ctx, pb.SubscriptionRequest_BLOCK, nil)
+	if err != nil {
+		cli.logger.Warningf("subscribe block to wait receipt of %s err: %s, fall back to polling", hash, err)
+		return cli.pollReceipt(ctx, hash)
+	}
+
+	// the tx may have been committed before the subscription is established
+	receipt, err := cli.getReceipt(ctx, cli.retryPolicy, hash)
+	if err == nil || !isPendingReceiptErr(err) {
+		return receipt, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait receipt of %s: %w", hash, ctx.Err())
+		case data, ok := <-blocks:
+			if !ok {
+				cli.logger.Warningf("block subscription to wait receipt of %s is closed, fall back to polling", hash)
+				return cli.pollReceipt(ctx, hash)
+			}
+			block, ok := data.(*pb.Block)
+			if !ok || !blockContainsTx(block, hash) {
+				continue
+			}
+			// the queried node may lag behind the one which pushed the block
+			return cli.pollReceipt(ctx, hash)
+		}
+	}
+}
+
+// pollReceipt queries the receipt with the configured interval until it is
+// found, ctx is done, or a query fails for good.
+func (cli *ChainClient) pollReceipt(ctx context.Context, hash string) (*pb.Receipt, error) {
+	ticker := time.NewTicker(cli.receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := cli.getReceipt(ctx, cli.retryPolicy, hash)
+		if err == nil || !isPendingReceiptErr(err) {
+			return receipt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait receipt of %s: %w, last error: %s", hash, ctx.Err(), err.Error())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isPendingReceiptErr tells whether a query of a receipt failed as its tx is
+// not committed yet or the node can't be reached for now, so that the wait
+// goes on.
+func isPendingReceiptErr(err error) bool {
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrRecoverable)
+}
+
+func blockContainsTx(block *pb.Block, hash string) bool {
+	if block.Transactions == nil {
+		return false
+	}
+	for _, tx := range block.Transactions.Transactions {
+		if tx != nil && tx.GetHash().String() == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package rpcx
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestChainClient_WaitForReceipt(t *testing.T) {
+	for name, subscribe := range map[string]codes.Code{
+		"subscription": codes.OK,
+		"polling":      codes.Unimplemented,
+	} {
+		subscribe := subscribe
+		t.Run(name, func(t *testing.T) {
+			broker, cli, _ := newBrokerClient(t, nil)
+			broker.SetCommitDelay(3 * time.Second)
+			broker.InjectFault("Subscribe", rpcxtest.Fault{Code: subscribe})
+
+			// a block slower than the GetReceipt retries is still waited for
+			to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+			tx, err := NewTxBuilder(cli.currentSigner().Address()).Transfer(to, big.NewInt(1)).Build()
+			require.Nil(t, err)
+			receipt, err := cli.SendTransactionWithReceipt(tx, nil)
+			require.Nil(t, err)
+			require.True(t, receipt.IsSuccess())
+		})
+	}
+}
+
+func TestChainClient_WaitForReceiptLaggingNode(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.SetCommitDelay(500 * time.Millisecond)
+	// the receipt is not found yet before the block, nor right after it
+	broker.InjectFault("GetReceipt", rpcxtest.Fault{Code: codes.NotFound, Times: 2})
+
+	hash, err := sendTestTransfer(cli)
+	require.Nil(t, err)
+	receipt, err := cli.WaitForReceipt(hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+}
+
+func TestChainClient_WaitForReceiptTimeout(t *testing.T) {
+	_, cli, _ := newBrokerClient(t, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := cli.WaitForReceiptCtx(ctx, types.NewHash([]byte("unknown")).String())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestChainClient_WaitForReceiptAttempts(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	hash, err := sendTestTransfer(cli)
+	require.Nil(t, err)
+
+	// a stalled query is bounded by GetReceiptTimeout rather than by the wait
+	broker.InjectFault("GetReceipt", rpcxtest.Fault{Delay: 2 * GetReceiptTimeout, Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 4*GetReceiptTimeout)
+	defer cancel()
+	start := time.Now()
+	receipt, err := cli.WaitForReceiptCtx(ctx, hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+	require.Less(t, time.Since(start), 2*GetReceiptTimeout)
+
+	// a query rejected by BitXHub ends the wait, whether it is polling or not
+	for _, subscribe := range []codes.Code{codes.OK, codes.Unimplemented} {
+		broker.InjectFault("Subscribe", rpcxtest.Fault{Code: subscribe})
+		broker.InjectFault("GetReceipt", rpcxtest.Fault{Code: codes.FailedPrecondition})
+		start = time.Now()
+		_, err = cli.WaitForReceiptCtx(ctx, hash)
+		require.ErrorIs(t, err, ErrReconstruct)
+		require.Less(t, time.Since(start), time.Second)
+	}
+}
@@ -0,0 +1,230 @@
+package rpcx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const (
+	defaultResubscribeBackoff    = 500 * time.Millisecond
+	defaultMaxResubscribeBackoff = 30 * time.Second
+
+	// backfillBatchSize is the max number of blocks fetched by one GetBlocks call
+	backfillBatchSize = 100
+)
+
+// SubscribeBlocksFrom delivers every block from height on, in order and exactly
+// once. If the stream breaks, it resubscribes through the connection pool with
+// backoff and fetches the blocks missed in between before following the live
+// stream again. If height is 0, it starts from the first live block.
+// The returned channel is closed only when ctx is done.
+func (cli *ChainClient) SubscribeBlocksFrom(ctx context.Context, height uint64) <-chan *pb.Block {
+	return resumeSubscription(ctx, cli, height, resumableSource[*pb.Block]{
+		name:      "block",
+		typ:       pb.SubscriptionRequest_BLOCK,
+		subscribe: cli.SubscribeBlocks,
+		backfill:  cli.backfillBlocks,
+		height: func(block *pb.Block) uint64 {
+			return block.Height()
+		},
+	})
+}
+
+// SubscribeBlockHeadersFrom delivers every block header from height on, in order
+// and exactly once, resuming across disconnections like SubscribeBlocksFrom.
+func (cli *ChainClient) SubscribeBlockHeadersFrom(ctx context.Context, height uint64) <-chan *pb.BlockHeader {
+	return resumeSubscription(ctx, cli, height, resumableSource[*pb.BlockHeader]{
+		name:      "block header",
+		typ:       pb.SubscriptionRequest_BLOCK_HEADER,
+		subscribe: cli.SubscribeBlockHeaders,
+		backfill:  cli.backfillBlockHeaders,
+		height: func(header *pb.BlockHeader) uint64 {
+			return header.Number
+		},
+	})
+}
+
+type resumableSource[T any] struct {
+	name      string
+	typ       pb.SubscriptionRequest_Type
+	subscribe func(ctx context.Context) (<-chan T, <-chan error)
+	// backfill passes the items in [begin, end] to deliver in order
+	backfill func(ctx context.Context, begin, end uint64, deliver func(T) error) error
+	height   func(T) uint64
+}
+
+type resumer[T any] struct {
+	cli  *ChainClient
+	src  resumableSource[T]
+	out  chan<- T
+	next uint64 // height of the next item to deliver, 0 if unknown yet
+}
+
+func resumeSubscription[T any](ctx context.Context, cli *ChainClient, height uint64, src resumableSource[T]) <-chan T {
+	c := make(chan T)
+	r := &resumer[T]{
+		cli:  cli,
+		src:  src,
+		out:  c,
+		next: height,
+	}
+
+	go func() {
+		defer close(c)
+		backoff := cli.resubscribeBackoff
+		for {
+			start := r.next
+			err := r.run(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if r.next != start {
+				backoff = cli.resubscribeBackoff
+			}
+			cli.logger.Warningf("%s subscription is broken at height %d: %v, resubscribe in %s", src.name, r.next, err, backoff)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			cli.metrics.SubscriptionReconnected(subscriptionType(src.typ))
+			backoff *= 2
+			if backoff > cli.maxResubscribeBackoff {
+				backoff = cli.maxResubscribeBackoff
+			}
+		}
+	}()
+
+	return c
+}
+
+// run subscribes once, fills the gap since the last delivered item and follows
+// the live stream until it breaks.
+func (r *resumer[T]) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	live, errC := r.src.subscribe(ctx)
+
+	// the subscription is set up before querying the chain height, so nothing
+	// committed in between is lost
+	if r.next != 0 {
+		meta, err := r.cli.GetChainMetaCtx(ctx)
+		if err != nil {
+			return err
+		}
+		if meta.Height >= r.next {
+			if err := r.backfill(ctx, meta.Height); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-live:
+			if !ok {
+				if err := <-errC; err != nil {
+					return err
+				}
+				return fmt.Errorf("stream closed")
+			}
+			if err := r.receive(ctx, item); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *resumer[T]) receive(ctx context.Context, item T) error {
+	height := r.src.height(item)
+	if r.next == 0 {
+		r.next = height
+	}
+	if height < r.next {
+		return nil
+	}
+	if height > r.next {
+		if err := r.backfill(ctx, height-1); err != nil {
+			return err
+		}
+	}
+	return r.deliver(ctx, item)
+}
+
+func (r *resumer[T]) backfill(ctx context.Context, end uint64) error {
+	begin := r.next
+	err := r.src.backfill(ctx, begin, end, func(item T) error {
+		height := r.src.height(item)
+		if height < r.next {
+			return nil
+		}
+		if height > r.next {
+			return fmt.Errorf("backfill got height %d, expect %d", height, r.next)
+		}
+		return r.deliver(ctx, item)
+	})
+	if err != nil {
+		return fmt.Errorf("backfill %s [%d, %d]: %w", r.src.name, begin, end, err)
+	}
+	if r.next <= end {
+		return fmt.Errorf("backfill %s [%d, %d]: stopped at %d", r.src.name, begin, end, r.next)
+	}
+	return nil
+}
+
+func (r *resumer[T]) deliver(ctx context.Context, item T) error {
+	select {
+	case r.out <- item:
+		r.next = r.src.height(item) + 1
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (cli *ChainClient) backfillBlocks(ctx context.Context, begin, end uint64, deliver func(*pb.Block) error) error {
+	for start := begin; start <= end; start += backfillBatchSize {
+		stop := start + backfillBatchSize - 1
+		if stop > end {
+			stop = end
+		}
+		resp, err := cli.GetBlocksCtx(ctx, start, stop, true)
+		if err != nil {
+			return err
+		}
+		for _, block := range resp.Blocks {
+			if err := deliver(block); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (cli *ChainClient) backfillBlockHeaders(ctx context.Context, begin, end uint64, deliver func(*pb.BlockHeader) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan *pb.BlockHeader, blockChanNumber)
+	if err := cli.GetBlockHeader(ctx, begin, end, ch); err != nil {
+		return err
+	}
+	for header := range ch {
+		if err := deliver(header); err != nil {
+			// unblock the sync goroutine until it sees the cancellation
+			go func() {
+				for range ch {
+				}
+			}()
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package rpcx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// mine commits n blocks with transfers from the account of cli.
+func mine(t *testing.T, cli *ChainClient, n int) {
+	for i := 0; i < n; i++ {
+		_, err := sendTestTransfer(cli)
+		require.Nil(t, err)
+	}
+}
+
+// setDown breaks the streams of broker and refuses new ones while it is down.
+func setDown(broker *rpcxtest.Broker, down bool) {
+	if !down {
+		broker.ClearFaults()
+		return
+	}
+	broker.InjectFault("Subscribe", rpcxtest.Fault{Code: codes.Unavailable})
+	broker.DropStreams()
+}
+
+func TestChainClient_SubscribeBlocksFrom(t *testing.T) {
+	// the broker holds the genesis block and two mined ones
+	broker, cli, _ := newBrokerClient(t, nil)
+	mine(t, cli, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	blocks := cli.SubscribeBlocksFrom(ctx, 2)
+	headers := cli.SubscribeBlockHeadersFrom(ctx, 2)
+
+	expect := func(from, to uint64) {
+		for i := from; i <= to; i++ {
+			select {
+			case block := <-blocks:
+				require.Equal(t, i, block.Height())
+			case <-ctx.Done():
+				t.Fatalf("block %d not received", i)
+			}
+			select {
+			case header := <-headers:
+				require.Equal(t, i, header.Number)
+			case <-ctx.Done():
+				t.Fatalf("block header %d not received", i)
+			}
+		}
+	}
+
+	// history is fetched before the live blocks
+	expect(2, 3)
+	mine(t, cli, 2)
+	expect(4, 5)
+
+	// blocks mined while the streams are broken are backfilled
+	setDown(broker, true)
+	mine(t, cli, 3)
+	setDown(broker, false)
+	expect(6, 8)
+	mine(t, cli, 2)
+	expect(9, 10)
+
+	cancel()
+	for range blocks {
+	}
+	for range headers {
+	}
+}
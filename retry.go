@@ -0,0 +1,178 @@
+package rpcx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryBackoff    = 200 * time.Millisecond
+	defaultMaxRetryBackoff = 2 * time.Second
+	defaultRetryJitter     = 0.2
+
+	// a receipt shows up only once its tx is committed, which GetReceipt
+	// waits for with at least this many attempts
+	minReceiptAttempts = 5
+)
+
+// RetryPolicy sets how the calls to BitXHub are retried. Only errors returned
+// by a node are retried, as the client already waits for a healthy node before
+// every call, and every attempt goes to a node picked anew.
+//
+// Calls which only read the state of BitXHub are retried with the policy of the
+// client. Transactions are sent once unless RetrySends is set, in which case a
+// send is retried only if its tx hash is unknown to BitXHub, so that a tx
+// whose response was lost is not sent twice. SendTransactions is never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call, including the first
+	// one. A call is not retried if it is 1.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, which doubles on every
+	// retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Jitter randomizes every delay by up to this fraction of it, in [0, 1].
+	Jitter float64
+	// AttemptTimeout bounds every attempt. If 0, every attempt is bounded by
+	// the default timeout of the call unless the caller sets a deadline.
+	AttemptTimeout time.Duration
+	// Retryable reports whether an attempt failed with err is retried,
+	// IsRetryable if nil.
+	Retryable func(err error) bool
+	// RetrySends makes SendTransaction and SendRawTransaction retried too.
+	RetrySends bool
+}
+
+// DefaultRetryPolicy returns the policy of a client without WithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: defaultRetryAttempts,
+		Backoff:     defaultRetryBackoff,
+		MaxBackoff:  defaultMaxRetryBackoff,
+		Jitter:      defaultRetryJitter,
+	}
+}
+
+// IsRetryable reports whether err may be fixed by retrying, that is if it is
+// ErrRecoverable, like the failures of a node and ErrTimeout.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRecoverable)
+}
+
+// normalize fills the unset fields of the policy.
+func (policy *RetryPolicy) normalize() {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MaxBackoff < policy.Backoff {
+		policy.MaxBackoff = policy.Backoff
+	}
+	if policy.Jitter < 0 {
+		policy.Jitter = 0
+	}
+	if policy.Jitter > 1 {
+		policy.Jitter = 1
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = IsRetryable
+	}
+}
+
+// backoff returns the delay before the retry after the given attempt.
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.Backoff
+	for i := 1; i < attempt && delay < policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > policy.MaxBackoff {
+		delay = policy.MaxBackoff
+	}
+	if policy.Jitter > 0 {
+		delay += time.Duration(policy.Jitter * (2*rand.Float64() - 1) * float64(delay))
+	}
+	return delay
+}
+
+// receipt returns the policy of GetReceipt, which also retries a receipt not
+// found, as the tx may not be committed yet.
+func (policy RetryPolicy) receipt() *RetryPolicy {
+	if policy.MaxAttempts < minReceiptAttempts {
+		policy.MaxAttempts = minReceiptAttempts
+	}
+	retryable := policy.Retryable
+	policy.Retryable = func(err error) bool {
+		return errors.Is(err, ErrNotFound) || retryable(err)
+	}
+	return &policy
+}
+
+// retry runs attempt until it succeeds, fails with an error the policy doesn't
+// retry, runs out of attempts, or ctx is done. It returns the error of the last
+// attempt.
+func (cli *ChainClient) retry(ctx context.Context, policy *RetryPolicy, attempt func(ctx context.Context, attempt int) error) error {
+	for n := 1; ; n++ {
+		err := attempt(ctx, n)
+		var rpcErr *RPCError
+		if err == nil || n >= policy.MaxAttempts || !errors.As(err, &rpcErr) || !policy.Retryable(err) {
+			return err
+		}
+
+		delay := policy.backoff(n)
+		cli.logger.Debugf("retry %s in %s for attempt %d failed: %s", rpcErr.Method, delay, n, err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// sendTx sends tx, retried only if the policy of the client has RetrySends
+// set. Before a retry, and after a retry failed, tx is looked up by its hash,
+// and the send succeeds if BitXHub has it already.
+func (cli *ChainClient) sendTx(ctx context.Context, tx *pb.BxhTransaction) (string, error) {
+	var hash string
+	send := func(ctx context.Context, broker pb.ChainBrokerClient) error {
+		msg, err := broker.SendTransaction(ctx, tx)
+		if err != nil {
+			return err
+		}
+		hash = msg.TxHash
+		return nil
+	}
+	if !cli.retryPolicy.RetrySends {
+		err := cli.callOnce(ctx, "SendTransaction", 1, SendTransactionTimeout, send)
+		return hash, err
+	}
+
+	txHash := tx.Hash().String()
+	err := cli.retry(ctx, cli.retryPolicy, func(ctx context.Context, attempt int) error {
+		if attempt > 1 && cli.hasTransaction(ctx, txHash) {
+			hash = txHash
+			return nil
+		}
+		err := cli.callOnce(ctx, "SendTransaction", attempt, SendTransactionTimeout, send)
+		if err != nil && attempt > 1 && cli.hasTransaction(ctx, txHash) {
+			hash = txHash
+			return nil
+		}
+		return err
+	})
+	return hash, err
+}
+
+// hasTransaction reports whether BitXHub has the tx of hash.
+func (cli *ChainClient) hasTransaction(ctx context.Context, hash string) bool {
+	err := cli.callOnce(ctx, "GetTransaction", 1, GetTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) error {
+		_, err := broker.GetTransaction(ctx, &pb.TransactionHashMsg{TxHash: hash})
+		return err
+	})
+	return err == nil
+}
@@ -0,0 +1,102 @@
+package rpcx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 400 * time.Millisecond}
+	policy.normalize()
+	for attempt, delay := range []time.Duration{100, 200, 400, 400} {
+		require.Equal(t, delay*time.Millisecond, policy.backoff(attempt+1))
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		require.GreaterOrEqual(t, delay, 50*time.Millisecond)
+		require.LessOrEqual(t, delay, 150*time.Millisecond)
+	}
+}
+
+func TestChainClient_Retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+	broker, cli, _ := newBrokerClient(t, nil, WithRetryPolicy(policy))
+
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Code: codes.Internal, Times: 2})
+	_, err := cli.GetChainID()
+	require.Nil(t, err)
+
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Code: codes.Internal, Times: 3})
+	_, err = cli.GetChainID()
+	require.ErrorIs(t, err, ErrBrokenNetwork)
+
+	// invalid requests are not retried
+	broker.InjectFault("GetChainMeta", rpcxtest.Fault{Code: codes.InvalidArgument, Times: 1})
+	_, err = cli.GetChainMeta()
+	require.ErrorIs(t, err, ErrReconstruct)
+	_, err = cli.GetChainMeta()
+	require.Nil(t, err)
+
+	// a receipt is waited for until its tx is committed
+	broker.SetCommitDelay(50 * time.Millisecond)
+	hash, err := sendTestTransfer(cli)
+	require.Nil(t, err)
+	receipt, err := cli.GetReceipt(hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+}
+
+func TestChainClient_RetryAttemptTimeout(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, AttemptTimeout: 100 * time.Millisecond}
+	broker, cli, _ := newBrokerClient(t, nil, WithRetryPolicy(policy))
+
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Delay: time.Second, Times: 1})
+	start := time.Now()
+	_, err := cli.GetChainID()
+	require.Nil(t, err)
+	require.Less(t, time.Since(start), time.Second)
+
+	// the deadline of the caller bounds all attempts
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Delay: time.Second, Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = cli.GetChainIDCtx(ctx)
+	require.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestChainClient_RetrySends(t *testing.T) {
+	// a send is not retried by default
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond}
+	broker, cli, _ := newBrokerClient(t, nil, WithRetryPolicy(policy))
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Internal, Times: 1})
+	_, err := sendTestTransfer(cli)
+	require.ErrorIs(t, err, ErrBrokenNetwork)
+
+	// a tx whose response is lost is sent once
+	policy.RetrySends = true
+	broker, cli, from := newBrokerClient(t, nil, WithRetryPolicy(policy))
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Internal, Lost: true, Times: 1})
+	hash, err := sendTestTransfer(cli)
+	require.Nil(t, err)
+	receipt, err := cli.GetReceipt(hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+	nonce, err := cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), nonce)
+
+	// a tx never received is sent again
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Internal, Times: 1})
+	_, err = sendTestTransfer(cli)
+	require.Nil(t, err)
+	nonce, err = cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), nonce)
+}
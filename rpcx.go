@@ -3,22 +3,18 @@ package rpcx
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/Rican7/retry"
-	"github.com/Rican7/retry/backoff"
-	"github.com/Rican7/retry/strategy"
 	"github.com/meshplus/bitxhub-kit/crypto"
 	"github.com/meshplus/bitxhub-kit/types"
 	"github.com/meshplus/bitxhub-model/pb"
-	grpcpool "github.com/processout/grpc-go-pool"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 const (
@@ -51,119 +47,92 @@ type Account struct {
 var _ Client = (*ChainClient)(nil)
 
 type ChainClient struct {
-	privateKey crypto.PrivateKey
+	// signerMu guards signer, which SetSigner and SetPrivateKey replace
+	signerMu   sync.RWMutex
+	signer     Signer
 	logger     Logger
 	pool       *ConnectionPool
 	ipfsClient *IPFSClient
+	// nonceManager is nil unless WithNonceManager is set
+	nonceManager        *NonceManager
+	receiptPollInterval time.Duration
+	verifyWrappers      bool
+	signDigests         map[pb.GetSignsRequest_Type]SignDigestFunc
+
+	resubscribeBackoff    time.Duration
+	maxResubscribeBackoff time.Duration
+	retryPolicy           *RetryPolicy
+	interceptors          []Interceptor
+	metrics               Metrics
 	//normalSeqNo int64
 	//ibtpSeqNo   int64
 }
 
 func (cli *ChainClient) GetTransactionByBlockHashAndIndex(blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetTransactionByBlockHashAndIndexCtx(context.Background(), blockHash, index)
+}
 
-	client, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := client.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
+func (cli *ChainClient) GetTransactionByBlockHashAndIndexCtx(ctx context.Context, blockHash string, index uint64) (*pb.GetTransactionResponse, error) {
 	request := &pb.TransactionBlockHashAndIndexMsg{
 		BlockHash: blockHash,
 		Index:     index,
 	}
-	msg, err := client.broker.GetTransactionByBlockHashAndIndex(ctx, request)
-	return msg, err
+	var msg *pb.GetTransactionResponse
+	err := cli.call(ctx, "GetTransactionByBlockHashAndIndex", SendTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		msg, err = broker.GetTransactionByBlockHashAndIndex(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
 }
 
 func (cli *ChainClient) GetTransactionByBlockNumberAndIndex(blockNum uint64, index uint64) (*pb.GetTransactionResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetTransactionByBlockNumberAndIndexCtx(context.Background(), blockNum, index)
+}
 
-	client, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := client.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
+func (cli *ChainClient) GetTransactionByBlockNumberAndIndexCtx(ctx context.Context, blockNum uint64, index uint64) (*pb.GetTransactionResponse, error) {
 	request := &pb.TransactionBlockNumberAndIndexMsg{
 		BlockNumber: blockNum,
 		Index:       index,
 	}
-	msg, err := client.broker.GetTransactionByBlockNumberAndIndex(ctx, request)
-	return msg, err
+	var msg *pb.GetTransactionResponse
+	err := cli.call(ctx, "GetTransactionByBlockNumberAndIndex", SendTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		msg, err = broker.GetTransactionByBlockNumberAndIndex(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
 }
 
 // SendRawTransaction send signed transaction
 func (cli *ChainClient) SendRawTransaction(tx *pb.BxhTransaction) (string, error) {
-	return cli.sendRawTransaction(tx)
+	return cli.SendRawTransactionCtx(context.Background(), tx)
 }
 
-func (cli *ChainClient) sendRawTransaction(tx *pb.BxhTransaction) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return "", fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	client, err := cli.pool.getClient()
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		if err = client.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
-	msg, err := client.broker.SendTransaction(ctx, tx)
-	if err != nil {
-		st := status.Convert(err)
-		switch st.Code() {
-		case codes.Unknown, codes.Internal:
-			return "", fmt.Errorf("%w: %s", ErrBrokenNetwork, st.Err().Error())
-		case codes.InvalidArgument:
-			return "", fmt.Errorf("%w: %s", ErrReconstruct, st.Err().Error())
-		default:
-			return "", err
-		}
-	}
+func (cli *ChainClient) SendRawTransactionCtx(ctx context.Context, tx *pb.BxhTransaction) (string, error) {
+	return cli.sendRawTransaction(ctx, tx)
+}
 
-	return msg.TxHash, err
+func (cli *ChainClient) sendRawTransaction(ctx context.Context, tx *pb.BxhTransaction) (string, error) {
+	return cli.sendTx(ctx, tx)
 }
 
 // SendRawTransactionWithReceipt send signed transaction with receipt
 func (cli *ChainClient) SendRawTransactionWithReceipt(tx *pb.BxhTransaction) (*pb.Receipt, error) {
-	txHash, err := cli.sendRawTransaction(tx)
+	return cli.SendRawTransactionWithReceiptCtx(context.Background(), tx)
+}
+
+func (cli *ChainClient) SendRawTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	txHash, err := cli.sendRawTransaction(ctx, tx)
 	if err != nil {
 		return nil, fmt.Errorf("send tx error: %w", err)
 	}
 
-	receipt, err := cli.GetReceipt(txHash)
+	receipt, err := cli.WaitForReceiptCtx(ctx, txHash)
 	if err != nil {
 		return nil, err
 	}
@@ -171,41 +140,68 @@ func (cli *ChainClient) SendRawTransactionWithReceipt(tx *pb.BxhTransaction) (*p
 }
 
 func (cli *ChainClient) SetCtxMetadata(ctx context.Context) (context.Context, error) {
-	addr, err := cli.privateKey.PublicKey().Address()
-	if err != nil {
-		return nil, fmt.Errorf("get client accout err: %v", err)
-	}
-
-	md := metadata.New(map[string]string{ACCOUNT_KEY: addr.String()})
+	md := metadata.New(map[string]string{ACCOUNT_KEY: cli.currentSigner().Address().String()})
 	accountCtx := metadata.NewOutgoingContext(ctx, md)
 	return accountCtx, nil
 }
 
-func (cli *ChainClient) GetAccountBalance(address string) (*pb.Response, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetAccountBalanceTimeout)
-	defer cancel()
+// withDefaultTimeout bounds ctx with the given timeout unless the caller has
+// already set a deadline on it.
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return withClientTimeout(ctx, timeout)
+}
 
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
+type callerDeadlineKey struct{}
+
+// callerDeadline is the deadline ctx had when it was given to the client.
+type callerDeadline struct {
+	deadline time.Time
+	ok       bool
+}
+
+// withClientTimeout bounds ctx with a timeout of the client, remembering the
+// deadline of the caller so that the client's one running out can be told
+// apart, see callerGaveUp.
+func withClientTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Value(callerDeadlineKey{}).(callerDeadline); !ok {
+		deadline, ok := ctx.Deadline()
+		ctx = context.WithValue(ctx, callerDeadlineKey{}, callerDeadline{deadline: deadline, ok: ok})
 	}
+	return context.WithTimeout(ctx, timeout)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
+// callerGaveUp tells whether ctx is done because of its caller rather than of
+// a timeout of the client.
+func callerGaveUp(ctx context.Context) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	caller, ok := ctx.Value(callerDeadlineKey{}).(callerDeadline)
+	if !ok {
+		return true
 	}
+	return caller.ok && !time.Now().Before(caller.deadline)
+}
+
+func (cli *ChainClient) GetAccountBalance(address string) (*pb.Response, error) {
+	return cli.GetAccountBalanceCtx(context.Background(), address)
+}
+
+func (cli *ChainClient) GetAccountBalanceCtx(ctx context.Context, address string) (*pb.Response, error) {
 	request := &pb.Address{
 		Address: address,
 	}
-	response, err := grpcClient.broker.GetAccountBalance(ctx, request)
+	var response *pb.Response
+	err := cli.call(ctx, "GetAccountBalance", GetAccountBalanceTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetAccountBalance(ctx, request)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
 	return response, nil
 }
 
@@ -227,12 +223,7 @@ func NewWithNoGlobalPool(opts ...Option) (*ChainClient, error) {
 		return nil, err
 	}
 
-	return &ChainClient{
-		privateKey: cfg.privateKey,
-		logger:     cfg.logger,
-		pool:       clientPool,
-		ipfsClient: ipfsClient,
-	}, nil
+	return newChainClient(cfg, clientPool, ipfsClient), nil
 }
 
 func New(opts ...Option) (*ChainClient, error) {
@@ -253,16 +244,41 @@ func New(opts ...Option) (*ChainClient, error) {
 		return nil, err
 	}
 
-	return &ChainClient{
-		privateKey: cfg.privateKey,
-		logger:     cfg.logger,
-		pool:       pool,
-		ipfsClient: ipfsClient,
-	}, nil
+	return newChainClient(cfg, pool, ipfsClient), nil
+}
+
+func newChainClient(cfg *config, pool *ConnectionPool, ipfsClient *IPFSClient) *ChainClient {
+	cli := &ChainClient{
+		signer:              cfg.signer,
+		logger:              cfg.logger,
+		pool:                pool,
+		ipfsClient:          ipfsClient,
+		receiptPollInterval: cfg.receiptPollInterval,
+		verifyWrappers:      cfg.verifyWrappers,
+		signDigests:         defaultSignDigests(),
+
+		resubscribeBackoff:    cfg.resubscribeBackoff,
+		maxResubscribeBackoff: cfg.maxResubscribeBackoff,
+		retryPolicy:           cfg.retryPolicy,
+		interceptors:          append([]Interceptor{metricsInterceptor(cfg.metrics)}, cfg.interceptors...),
+		metrics:               cfg.metrics,
+	}
+	for typ, digest := range cfg.signDigests {
+		cli.signDigests[typ] = digest
+	}
+	if cfg.nonceManager {
+		cli.nonceManager = NewNonceManager(cli.GetPendingNonceByAccountCtx)
+	}
+	return cli
+}
+
+// NonceManager returns the local nonce cache, or nil if WithNonceManager is not set.
+func (cli *ChainClient) NonceManager() *NonceManager {
+	return cli.nonceManager
 }
 
 func (cli *ChainClient) Stop() error {
-	if cli.pool.pool.IsClosed() {
+	if cli.pool == nil || cli.pool.IsClosed() {
 		cli.logger.Warningf("client has been closed")
 		return nil
 	}
@@ -275,38 +291,61 @@ func (cli *ChainClient) Stop() error {
 }
 
 func (cli *ChainClient) SendView(tx *pb.BxhTransaction) (*pb.Receipt, error) {
-	return cli.sendView(tx)
+	return cli.SendViewCtx(context.Background(), tx)
+}
+
+func (cli *ChainClient) SendViewCtx(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	return cli.sendView(ctx, tx)
 }
 
 func (cli *ChainClient) SendTransaction(tx *pb.BxhTransaction, opts *TransactOpts) (string, error) {
-	return cli.sendTransaction(tx, opts)
+	return cli.SendTransactionCtx(context.Background(), tx, opts)
+}
+
+func (cli *ChainClient) SendTransactionCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (string, error) {
+	return cli.sendTransaction(ctx, tx, opts)
 }
 
 func (cli *ChainClient) SendTransactions(txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
-	return cli.sendTransactions(txs)
+	return cli.SendTransactionsCtx(context.Background(), txs)
+}
+
+func (cli *ChainClient) SendTransactionsCtx(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+	return cli.sendTransactions(ctx, txs)
 }
 
 func (cli *ChainClient) SendTransactionWithReceipt(tx *pb.BxhTransaction, opts *TransactOpts) (*pb.Receipt, error) {
-	return cli.sendTransactionWithReceipt(tx, opts)
+	return cli.SendTransactionWithReceiptCtx(context.Background(), tx, opts)
+}
+
+func (cli *ChainClient) SendTransactionWithReceiptCtx(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (*pb.Receipt, error) {
+	return cli.sendTransactionWithReceipt(ctx, tx, opts)
 }
 
 // GetReceipts get receipts by tx hashes
 func (cli *ChainClient) GetReceipt(hash string) (*pb.Receipt, error) {
-	var receipt *pb.Receipt
-	var err error
-
-	err = retry.Retry(func(attempt uint) error {
-		receipt, err = cli.getReceipt(hash)
-		if err != nil {
-			return err
-		}
+	return cli.GetReceiptCtx(context.Background(), hash)
+}
 
-		return nil
-	},
-		strategy.Limit(5),
-		strategy.Backoff(backoff.Fibonacci(500*time.Millisecond)),
-	)
+func (cli *ChainClient) GetReceiptCtx(ctx context.Context, hash string) (*pb.Receipt, error) {
+	return cli.getReceipt(ctx, cli.retryPolicy.receipt(), hash)
+}
 
+// getReceipt gets the receipt of hash, retried with policy. Every attempt is
+// bounded by GetReceiptTimeout, even if ctx has a longer deadline, like the one
+// of a wait for the receipt.
+func (cli *ChainClient) getReceipt(ctx context.Context, policy *RetryPolicy, hash string) (*pb.Receipt, error) {
+	var receipt *pb.Receipt
+	err := cli.retry(ctx, policy, func(ctx context.Context, attempt int) error {
+		ctx, cancel := withClientTimeout(ctx, GetReceiptTimeout)
+		defer cancel()
+		return cli.callOnce(ctx, "GetReceipt", attempt, GetReceiptTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+			receipt, err = broker.GetReceipt(ctx, &pb.TransactionHashMsg{
+				TxHash: hash,
+			})
+			return err
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -315,308 +354,239 @@ func (cli *ChainClient) GetReceipt(hash string) (*pb.Receipt, error) {
 }
 
 func (cli *ChainClient) GetTransaction(hash string) (*pb.GetTransactionResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetTransactionTimeout)
-	defer cancel()
+	return cli.GetTransactionCtx(context.Background(), hash)
+}
 
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+func (cli *ChainClient) GetTransactionCtx(ctx context.Context, hash string) (*pb.GetTransactionResponse, error) {
 
-	grpcClient, err := cli.pool.getClient()
+	var response *pb.GetTransactionResponse
+	err := cli.call(ctx, "GetTransaction", GetTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetTransaction(ctx, &pb.TransactionHashMsg{
+			TxHash: hash,
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	return response, nil
+}
 
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetTransaction(ctx, &pb.TransactionHashMsg{
-		TxHash: hash,
-	})
+// SetPrivateKey makes the client sign its transactions with key. A key whose
+// address can't be derived is logged and ignored, leaving the signer unchanged.
+func (cli *ChainClient) SetPrivateKey(key crypto.PrivateKey) {
+	signer, err := NewKeySigner(key)
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		cli.logger.Errorf("set private key err: %s", err)
+		return
 	}
-	return response, nil
+	cli.SetSigner(signer)
 }
 
-func (cli *ChainClient) SetPrivateKey(key crypto.PrivateKey) {
-	cli.privateKey = key
+// SetSigner sets the signer of the transactions sent by the client.
+func (cli *ChainClient) SetSigner(signer Signer) {
+	cli.signerMu.Lock()
+	defer cli.signerMu.Unlock()
+	cli.signer = signer
 }
 
-func (cli *ChainClient) GetChainMeta() (*pb.ChainMeta, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// currentSigner returns the signer of the client, which may be replaced
+// concurrently by SetSigner.
+func (cli *ChainClient) currentSigner() Signer {
+	cli.signerMu.RLock()
+	defer cli.signerMu.RUnlock()
+	return cli.signer
+}
 
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+func (cli *ChainClient) GetChainMeta() (*pb.ChainMeta, error) {
+	return cli.GetChainMetaCtx(context.Background())
+}
 
-	grpcClient, err := cli.pool.getClient()
+func (cli *ChainClient) GetChainMetaCtx(ctx context.Context) (*pb.ChainMeta, error) {
+	var response *pb.ChainMeta
+	err := cli.call(ctx, "GetChainMeta", 10*time.Second, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetChainMeta(ctx, &pb.Request{})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetChainMeta(ctx, &pb.Request{})
-	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
 	return response, nil
 }
 
-func (cli *ChainClient) sendTransactionWithReceipt(tx *pb.BxhTransaction, opts *TransactOpts) (*pb.Receipt, error) {
-	hash, err := cli.sendTransaction(tx, opts)
+func (cli *ChainClient) sendTransactionWithReceipt(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (*pb.Receipt, error) {
+	hash, err := cli.sendTransaction(ctx, tx, opts)
 	if err != nil {
 		return nil, fmt.Errorf("send tx error: %w", err)
 	}
 
-	receipt, err := cli.GetReceipt(hash)
+	receipt, err := cli.WaitForReceiptCtx(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
 	return receipt, nil
 }
 
-func (cli *ChainClient) sendTransaction(tx *pb.BxhTransaction, opts *TransactOpts) (string, error) {
+func (cli *ChainClient) sendTransaction(ctx context.Context, tx *pb.BxhTransaction, opts *TransactOpts) (string, error) {
 	if tx.From == nil {
 		return "", fmt.Errorf("%w: from address can't be empty", ErrReconstruct)
 	}
 	if opts == nil {
 		opts = new(TransactOpts)
 		opts.From = tx.From.String() // set default from for opts
-		opts.PrivKey = cli.privateKey
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
+	signer, err := cli.signerOf(opts)
 	if err != nil {
-		return "", fmt.Errorf("set ctx metadata err: %v", err)
+		return "", fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
 	}
-	var nonce uint64
+
+	var (
+		nonce   uint64
+		managed bool
+	)
 	if opts.Nonce == 0 {
-		// no nonce set for tx, then use latest nonce from bitxhub
-		nonce, err = cli.GetPendingNonceByAccount(opts.From)
+		if cli.nonceManager != nil {
+			// no nonce set for tx, then use the next nonce cached locally
+			nonce, err = cli.nonceManager.Next(ctx, opts.From)
+			managed = true
+		} else {
+			// no nonce set for tx, then use latest nonce from bitxhub
+			nonce, err = cli.GetPendingNonceByAccountCtx(ctx, opts.From)
+		}
 		if err != nil {
-			return "", fmt.Errorf("%w: failed to retrieve nonce for account %s for %s", ErrBrokenNetwork, opts.From, err.Error())
+			return "", fmt.Errorf("failed to retrieve nonce for account %s: %w", opts.From, err)
 		}
 	} else {
 		nonce = opts.Nonce
 	}
 	tx.Nonce = nonce
 
-	if opts.PrivKey == nil {
-		opts.PrivKey = cli.privateKey
-	}
-	if err := tx.Sign(opts.PrivKey); err != nil {
+	if err := signTx(tx, signer); err != nil {
+		if managed {
+			cli.nonceManager.Release(opts.From, nonce)
+		}
 		return "", fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
 	}
 
-	grpcClient, err := cli.pool.getClient()
+	hash, err := cli.sendTx(ctx, tx)
 	if err != nil {
+		if managed {
+			cli.handleNonceErr(ctx, opts.From, nonce, err)
+		}
 		return "", err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
-	msg, err := grpcClient.broker.SendTransaction(ctx, tx)
-	if err != nil {
-		st := status.Convert(err)
-		switch st.Code() {
-		case codes.Unknown, codes.Internal:
-			return "", fmt.Errorf("%w: %s", ErrBrokenNetwork, st.Err().Error())
-		case codes.InvalidArgument:
-			return "", fmt.Errorf("%w: %s", ErrReconstruct, st.Err().Error())
-		default:
-			return "", err
-		}
+	if managed {
+		cli.nonceManager.Sent(opts.From)
 	}
 
-	return msg.TxHash, err
+	return hash, nil
 }
 
-func (cli *ChainClient) sendTransactions(txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
-	msg, err := grpcClient.broker.SendTransactions(ctx, txs)
-	if err != nil {
-		st := status.Convert(err)
-		switch st.Code() {
-		case codes.Unknown, codes.Internal:
-			return nil, fmt.Errorf("%w: %s", ErrBrokenNetwork, st.Err().Error())
-		case codes.InvalidArgument:
-			return nil, fmt.Errorf("%w: %s", ErrReconstruct, st.Err().Error())
-		default:
-			return nil, err
+// handleNonceErr keeps the local nonce cache consistent after a failed send:
+// the nonce is given back if the tx never reached BitXHub or was rejected, and
+// the cache is reloaded if BitXHub rejected the nonce. If the node may have
+// accepted the tx, like when the send timed out or the connection broke, the
+// cache is reloaded once the other sends of the account in flight are done.
+func (cli *ChainClient) handleNonceErr(ctx context.Context, account string, nonce uint64, err error) {
+	var rpcErr *RPCError
+	switch {
+	case !errors.As(err, &rpcErr):
+		// no node was available to send the tx to
+		cli.nonceManager.Release(account, nonce)
+	case errors.Is(err, ErrNonceTooLow):
+		if err := cli.nonceManager.Resync(ctx, account); err != nil {
+			// the nonce is reloaded on next use
+			cli.logger.Warningf("resync nonce for account %s err: %s", account, err)
 		}
+		// the nonce was handed out before the resync, so it is just settled
+		cli.nonceManager.Release(account, nonce)
+	case errors.Is(err, ErrRecoverable):
+		cli.nonceManager.Invalidate(account)
+	default:
+		cli.nonceManager.Release(account, nonce)
 	}
-	return msg, nil
 }
 
-func (cli *ChainClient) sendView(tx *pb.BxhTransaction) (*pb.Receipt, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendTransactionTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	grpcClient, err := cli.pool.getClient()
+// sendTransactions is never retried, as BitXHub may have accepted a part of txs.
+func (cli *ChainClient) sendTransactions(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+	var msg *pb.MultiTransactionHash
+	err := cli.callOnce(ctx, "SendTransactions", 1, SendTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		msg, err = broker.SendTransactions(ctx, txs)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	if err := tx.Sign(cli.privateKey); err != nil {
-		return nil, fmt.Errorf("tx sign: %w", err)
-	}
-
-	receipt, err := grpcClient.broker.SendView(ctx, tx)
-	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
-
-	return receipt, nil
+	return msg, nil
 }
 
-func (cli *ChainClient) getReceipt(hash string) (*pb.Receipt, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetReceiptTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
+func (cli *ChainClient) sendView(ctx context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	if err := signTx(tx, cli.currentSigner()); err != nil {
+		return nil, fmt.Errorf("tx sign: %w", err)
 	}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetReceipt(ctx, &pb.TransactionHashMsg{
-		TxHash: hash,
+	var receipt *pb.Receipt
+	err := cli.call(ctx, "SendView", SendTransactionTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		receipt, err = broker.SendView(ctx, tx)
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrBrokenNetwork, err.Error())
+		return nil, err
 	}
-	return response, nil
+
+	return receipt, nil
 }
 
 func (cli *ChainClient) GetMultiSigns(content string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendMultiSignsTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetMultiSignsCtx(context.Background(), content, typ)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetMultiSigns(ctx, &pb.GetSignsRequest{
-		Content: content,
-		Type:    typ,
+func (cli *ChainClient) GetMultiSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type) (*pb.SignResponse, error) {
+	var response *pb.SignResponse
+	err := cli.call(ctx, "GetMultiSigns", SendMultiSignsTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetMultiSigns(ctx, &pb.GetSignsRequest{
+			Content: content,
+			Type:    typ,
+		})
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
 	return response, nil
 }
 
 func (cli *ChainClient) GetTssSigns(content string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), SendMultiSignsTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetTssSignsCtx(context.Background(), content, typ, extra)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	response, err := grpcClient.broker.GetTssSigns(ctx, &pb.GetSignsRequest{
-		Content: content,
-		Type:    typ,
-		Extra:   extra,
+func (cli *ChainClient) GetTssSignsCtx(ctx context.Context, content string, typ pb.GetSignsRequest_Type, extra []byte) (*pb.SignResponse, error) {
+	var response *pb.SignResponse
+	err := cli.call(ctx, "GetTssSigns", SendMultiSignsTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		response, err = broker.GetTssSigns(ctx, &pb.GetSignsRequest{
+			Content: content,
+			Type:    typ,
+			Extra:   extra,
+		})
+		return err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
 	return response, nil
 }
 
 func (cli *ChainClient) GetPendingNonceByAccount(account string) (uint64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetInfoTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetPendingNonceByAccountCtx(context.Background(), account)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			if err != grpcpool.ErrAlreadyClosed {
-				cli.logger.Errorf("close conn err: %s", err)
-			}
-		}
-	}()
-	res, err := grpcClient.broker.GetPendingNonceByAccount(ctx, &pb.Address{
-		Address: account,
+func (cli *ChainClient) GetPendingNonceByAccountCtx(ctx context.Context, account string) (uint64, error) {
+	var res *pb.Response
+	err := cli.call(ctx, "GetPendingNonceByAccount", GetInfoTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		res, err = broker.GetPendingNonceByAccount(ctx, &pb.Address{
+			Address: account,
+		})
+		return err
 	})
 	if err != nil {
 		return 0, err
@@ -629,30 +599,20 @@ func CheckReceipt(receipt *pb.Receipt) bool {
 }
 
 func (cli *ChainClient) GetTPS(begin, end uint64) (uint64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetTPSTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetTPSCtx(context.Background(), begin, end)
+}
 
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	resp, err := grpcClient.broker.GetTPS(ctx, &pb.GetTPSRequest{
-		Begin: begin,
-		End:   end,
+func (cli *ChainClient) GetTPSCtx(ctx context.Context, begin, end uint64) (uint64, error) {
+	var resp *pb.Response
+	err := cli.call(ctx, "GetTPS", GetTPSTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		resp, err = broker.GetTPS(ctx, &pb.GetTPSRequest{
+			Begin: begin,
+			End:   end,
+		})
+		return err
 	})
-
 	if err != nil {
-		return 0, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return 0, err
 	}
 
 	if resp == nil {
@@ -668,28 +628,18 @@ func (cli *ChainClient) GetTPS(begin, end uint64) (uint64, error) {
 }
 
 func (cli *ChainClient) GetChainID() (uint64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GetChainIDTimeout)
-	defer cancel()
-
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return 0, fmt.Errorf("set ctx metadata err: %v", err)
-	}
+	return cli.GetChainIDCtx(context.Background())
+}
 
-	grpcClient, err := cli.pool.getClient()
+func (cli *ChainClient) GetChainIDCtx(ctx context.Context) (uint64, error) {
+	var resp *pb.Response
+	err := cli.call(ctx, "GetChainID", GetChainIDTimeout, func(ctx context.Context, broker pb.ChainBrokerClient) (err error) {
+		resp, err = broker.GetChainID(ctx, &pb.Empty{})
+		return err
+	})
 	if err != nil {
 		return 0, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-	resp, err := grpcClient.broker.GetChainID(ctx, &pb.Empty{})
-
-	if err != nil {
-		return 0, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
-	}
 
 	if resp == nil || resp.Data == nil {
 		return 0, fmt.Errorf("empty response")
@@ -1,6 +1,7 @@
 package rpcx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -120,7 +121,7 @@ func TestChainClient_SendView(t *testing.T) {
 
 	// test sending write-ledger tx to SendView api
 	// bitxhub will execute this tx, but its result will not be persisted in storage
-	receipt, err := cli.sendView(tx)
+	receipt, err := cli.sendView(context.Background(), tx)
 	require.Nil(t, err)
 	require.Equal(t, pb.Receipt_SUCCESS, receipt.Status, string(receipt.Ret))
 
@@ -236,21 +237,15 @@ func transferFromAdmin(client *ChainClient, address *types.Address, amount strin
 		return err
 	}
 
-	data := &pb.TransactionData{
-		Amount: amount,
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return fmt.Errorf("invalid amount %s", amount)
 	}
-	payload, err := data.Marshal()
+	tx, err := NewTxBuilder(adminFrom).Transfer(address, value).Build()
 	if err != nil {
 		return err
 	}
 
-	tx := &pb.BxhTransaction{
-		From:      adminFrom,
-		To:        address,
-		Timestamp: time.Now().UnixNano(),
-		Payload:   payload,
-	}
-
 	adminNonce, err := client.GetPendingNonceByAccount(adminFrom.String())
 	if err != nil {
 		return err
@@ -270,6 +265,26 @@ func transferFromAdmin(client *ChainClient, address *types.Address, amount strin
 	return nil
 }
 
+func TestWithDefaultTimeout(t *testing.T) {
+	ctx, cancel := withDefaultTimeout(context.Background(), time.Second)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	require.True(t, time.Until(deadline) <= time.Second)
+
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer parentCancel()
+	ctx, cancel = withDefaultTimeout(parent, time.Second)
+	defer cancel()
+	deadline, ok = ctx.Deadline()
+	require.True(t, ok)
+	require.True(t, time.Until(deadline) > time.Second)
+
+	parentCancel()
+	<-ctx.Done()
+	require.Equal(t, context.Canceled, ctx.Err())
+}
+
 func TestChainClient_GetChainMeta(t *testing.T) {
 	cli, err := Cli()
 	require.Nil(t, err)
@@ -358,7 +373,7 @@ func TestChainClient_GetTPS(t *testing.T) {
 	err = tx.Sign(privKey)
 	require.Nil(t, err)
 
-	_, err = cli.sendTransactionWithReceipt(tx, nil)
+	_, err = cli.sendTransactionWithReceipt(context.Background(), tx, nil)
 	require.Nil(t, err)
 
 	meta0, err := cli.GetChainMeta()
@@ -372,7 +387,7 @@ func TestChainClient_GetTPS(t *testing.T) {
 		err = tx.Sign(privKey)
 		require.Nil(t, err)
 
-		_, err = cli.sendTransaction(tx, nil)
+		_, err = cli.sendTransaction(context.Background(), tx, nil)
 		require.Nil(t, err)
 
 		time.Sleep(time.Second)
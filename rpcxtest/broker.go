@@ -0,0 +1,544 @@
+// Package rpcxtest provides an in-memory BitXHub node for testing clients of
+// BitXHub without a network. Broker serves pb.ChainBrokerServer on a loopback
+// address, so the real ChainClient of package rpcx can be pointed at it:
+//
+//	broker := rpcxtest.NewBroker()
+//	if err := broker.Start(); err != nil {
+//		...
+//	}
+//	defer broker.Stop()
+//	broker.SetBalance(addr, big.NewInt(1000))
+//	cli, err := rpcx.New(
+//		rpcx.WithNodesInfo(&rpcx.NodeInfo{Addr: broker.Addr()}),
+//		rpcx.WithPrivateKey(key),
+//	)
+//
+// Every transaction is executed and committed in a block of its own as soon as
+// it is sent, or after the delay set by SetCommitDelay. Failures of the calls
+// can be injected with InjectFault and DropStreams.
+package rpcxtest
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultChainID is the chain ID of a Broker unless set by WithChainID.
+const DefaultChainID = 1356
+
+// InvokeHandler executes an invocation of method with args on a contract,
+// returning the return value of the receipt. An error fails the receipt with
+// the error message as return value.
+type InvokeHandler func(tx *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error)
+
+type Option func(*Broker)
+
+func WithChainID(chainID uint64) Option {
+	return func(b *Broker) {
+		b.chainID = chainID
+	}
+}
+
+// Broker is an in-memory BitXHub node. It keeps blocks, transactions,
+// receipts, nonces and balances, and executes transfers, contract invocations
+// through the handlers registered by HandleInvoke, and IBTPs, which are
+// grouped into interchain tx wrappers by the chain ID of their destination.
+// Like the transaction manager contract of BitXHub, it tracks the status of
+// the interchain transaction of every IBTP, served by the "GetStatus" method
+// of constant.TransactionMgrContractAddr. A transaction with a nonce lower
+// than expected is rejected, while one with a higher nonce stays pending until
+// the transactions before it arrive.
+type Broker struct {
+	pb.UnimplementedChainBrokerServer
+
+	chainID uint64
+
+	mu        sync.Mutex
+	blocks    []*pb.Block
+	wrappers  []map[string]*pb.InterchainTxWrapper
+	txs       map[string]*pb.GetTransactionResponse
+	receipts  map[string]*pb.Receipt
+	nonces    map[string]uint64
+	balances  map[string]*big.Int
+	contracts map[string]InvokeHandler
+	statuses  map[string]pb.TransactionStatus
+	// committed is closed and replaced on every new block
+	committed chan struct{}
+
+	faults      map[string]*Fault
+	streams     map[int]context.CancelFunc
+	streamID    int
+	commitDelay time.Duration
+	// pending are the accepted transactions waiting for commitDelay
+	pending map[string]struct{}
+	// queued are the transactions of every account waiting for the nonces
+	// before theirs, by nonce
+	queued map[string]map[uint64]*pb.BxhTransaction
+
+	server   *grpc.Server
+	listener net.Listener
+}
+
+var _ pb.ChainBrokerServer = (*Broker)(nil)
+
+// NewBroker returns a broker holding the genesis block only.
+func NewBroker(opts ...Option) *Broker {
+	b := &Broker{
+		chainID:   DefaultChainID,
+		txs:       make(map[string]*pb.GetTransactionResponse),
+		receipts:  make(map[string]*pb.Receipt),
+		nonces:    make(map[string]uint64),
+		balances:  make(map[string]*big.Int),
+		contracts: make(map[string]InvokeHandler),
+		statuses:  make(map[string]pb.TransactionStatus),
+		committed: make(chan struct{}),
+		faults:    make(map[string]*Fault),
+		streams:   make(map[int]context.CancelFunc),
+		pending:   make(map[string]struct{}),
+		queued:    make(map[string]map[uint64]*pb.BxhTransaction),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.commit(nil, nil)
+	return b
+}
+
+// Start serves the broker on a loopback address, returned by Addr.
+func (b *Broker) Start() error {
+	return b.StartWith(b)
+}
+
+// StartWith serves srv instead of the broker on a loopback address, with the
+// faults injected into the broker. srv usually embeds the broker to override
+// some of its methods, like a node serving forged data:
+//
+//	type forgingBroker struct {
+//		*rpcxtest.Broker
+//	}
+//
+//	func (b *forgingBroker) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.Block, error) {
+//		...
+//	}
+//
+//	broker := rpcxtest.NewBroker()
+//	err := broker.StartWith(&forgingBroker{Broker: broker})
+func (b *Broker) StartWith(srv pb.ChainBrokerServer) error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	b.listener = lis
+	b.server = grpc.NewServer(
+		grpc.UnaryInterceptor(b.unaryInterceptor),
+		grpc.StreamInterceptor(b.streamInterceptor),
+	)
+	pb.RegisterChainBrokerServer(b.server, srv)
+	go func() {
+		_ = b.server.Serve(lis)
+	}()
+	return nil
+}
+
+func (b *Broker) Addr() string {
+	if b.listener == nil {
+		return ""
+	}
+	return b.listener.Addr().String()
+}
+
+// Stop stops serving, closing all connections and streams.
+func (b *Broker) Stop() {
+	if b.server != nil {
+		b.server.Stop()
+	}
+}
+
+// SetBalance sets the balance of the account address.
+func (b *Broker) SetBalance(address *types.Address, balance *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.balances[address.String()] = new(big.Int).Set(balance)
+}
+
+func (b *Broker) Balance(address *types.Address) *big.Int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return new(big.Int).Set(b.balanceOf(address.String()))
+}
+
+// HandleInvoke lets handler execute the invocations of the contract at
+// address, both by transactions and by views.
+func (b *Broker) HandleInvoke(address *types.Address, handler InvokeHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.contracts[address.String()] = handler
+}
+
+// Height returns the height of the latest block.
+func (b *Broker) Height() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return uint64(len(b.blocks))
+}
+
+func (b *Broker) balanceOf(address string) *big.Int {
+	balance, ok := b.balances[address]
+	if !ok {
+		return new(big.Int)
+	}
+	return balance
+}
+
+func (b *Broker) SendTransaction(_ context.Context, tx *pb.BxhTransaction) (*pb.TransactionHashMsg, error) {
+	if tx.From == nil || tx.To == nil {
+		return nil, status.Error(codes.InvalidArgument, "tx from and to address is required")
+	}
+	if err := tx.VerifySignature(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid signature: %s", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from := tx.From.String()
+	expected := b.nonces[from] + 1
+	if tx.Nonce < expected {
+		return nil, status.Errorf(codes.InvalidArgument, "nonce too low: %d of account %s, expected %d", tx.Nonce, from, expected)
+	}
+	hash := tx.Hash().String()
+	_, pending := b.pending[hash]
+	if _, ok := b.txs[hash]; ok || pending {
+		return nil, status.Errorf(codes.InvalidArgument, "tx %s already exists", hash)
+	}
+	queue := b.queued[from]
+	if queued, ok := queue[tx.Nonce]; ok {
+		if queued.Hash().String() == hash {
+			return nil, status.Errorf(codes.InvalidArgument, "tx %s already exists", hash)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "nonce %d of account %s is already used by a pending tx", tx.Nonce, from)
+	}
+
+	if tx.Nonce > expected {
+		// like the tx pool of BitXHub, a tx waits for the nonces before it
+		if queue == nil {
+			queue = make(map[uint64]*pb.BxhTransaction)
+			b.queued[from] = queue
+		}
+		queue[tx.Nonce] = tx
+		return &pb.TransactionHashMsg{TxHash: hash}, nil
+	}
+
+	b.accept(tx)
+	for {
+		next, ok := queue[b.nonces[from]+1]
+		if !ok {
+			break
+		}
+		delete(queue, next.Nonce)
+		b.accept(next)
+	}
+	if len(queue) == 0 {
+		delete(b.queued, from)
+	}
+	return &pb.TransactionHashMsg{TxHash: hash}, nil
+}
+
+// accept counts tx in the nonce of its sender and commits it, after the
+// commit delay if any. b.mu must be held.
+func (b *Broker) accept(tx *pb.BxhTransaction) {
+	b.nonces[tx.From.String()] = tx.Nonce
+	if b.commitDelay == 0 {
+		b.commit(tx, b.execute(tx, false))
+		return
+	}
+	hash := tx.Hash().String()
+	b.pending[hash] = struct{}{}
+	time.AfterFunc(b.commitDelay, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.pending, hash)
+		b.commit(tx, b.execute(tx, false))
+	})
+}
+
+func (b *Broker) SendTransactions(ctx context.Context, txs *pb.MultiTransaction) (*pb.MultiTransactionHash, error) {
+	hashes := &pb.MultiTransactionHash{}
+	for _, tx := range txs.Txs {
+		msg, err := b.SendTransaction(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		hashes.TxHashList = append(hashes.TxHashList, msg)
+	}
+	return hashes, nil
+}
+
+func (b *Broker) SendView(_ context.Context, tx *pb.BxhTransaction) (*pb.Receipt, error) {
+	if tx.To == nil {
+		return nil, status.Error(codes.InvalidArgument, "tx to address is required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.execute(tx, true), nil
+}
+
+func (b *Broker) GetTransaction(_ context.Context, msg *pb.TransactionHashMsg) (*pb.GetTransactionResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	resp, ok := b.txs[msg.TxHash]
+	if !ok {
+		return nil, status.Errorf(codes.Unknown, "tx %s not found in DB", msg.TxHash)
+	}
+	return resp, nil
+}
+
+func (b *Broker) GetReceipt(_ context.Context, msg *pb.TransactionHashMsg) (*pb.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	receipt, ok := b.receipts[msg.TxHash]
+	if !ok {
+		return nil, status.Errorf(codes.Unknown, "receipt of %s not found in DB", msg.TxHash)
+	}
+	return receipt, nil
+}
+
+func (b *Broker) GetBlock(_ context.Context, req *pb.GetBlockRequest) (*pb.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch req.Type {
+	case pb.GetBlockRequest_LATEST:
+		return b.blocks[len(b.blocks)-1], nil
+	case pb.GetBlockRequest_HEIGHT:
+		height, err := strconv.ParseUint(req.Value, 10, 64)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid block height %s", req.Value)
+		}
+		if height == 0 || height > uint64(len(b.blocks)) {
+			return nil, status.Errorf(codes.Unknown, "block %d not found in DB", height)
+		}
+		return b.blocks[height-1], nil
+	case pb.GetBlockRequest_HASH:
+		for _, block := range b.blocks {
+			if block.BlockHash.String() == req.Value {
+				return block, nil
+			}
+		}
+		return nil, status.Errorf(codes.Unknown, "block %s not found in DB", req.Value)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown block request type %s", req.Type)
+	}
+}
+
+func (b *Broker) GetBlocks(_ context.Context, req *pb.GetBlocksRequest) (*pb.GetBlocksResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	resp := &pb.GetBlocksResponse{}
+	for height := req.Start; height <= req.End && height <= uint64(len(b.blocks)); height++ {
+		if height == 0 {
+			continue
+		}
+		resp.Blocks = append(resp.Blocks, b.blocks[height-1])
+	}
+	return resp, nil
+}
+
+func (b *Broker) GetChainMeta(context.Context, *pb.Request) (*pb.ChainMeta, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	latest := b.blocks[len(b.blocks)-1]
+	var count uint64
+	for _, resp := range b.txs {
+		if resp.Tx.IBTP != nil {
+			count++
+		}
+	}
+	return &pb.ChainMeta{
+		Height:            latest.BlockHeader.Number,
+		BlockHash:         latest.BlockHash,
+		InterchainTxCount: count,
+	}, nil
+}
+
+func (b *Broker) GetAccountBalance(_ context.Context, addr *pb.Address) (*pb.Response, error) {
+	address := types.NewAddressByStr(addr.Address)
+	if address == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account address %s", addr.Address)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// the accounts are keyed by the checksummed address
+	data := fmt.Sprintf(`{"type":"normal","balance":%s}`, b.balanceOf(address.String()).String())
+	return &pb.Response{Data: []byte(data)}, nil
+}
+
+func (b *Broker) GetPendingNonceByAccount(_ context.Context, addr *pb.Address) (*pb.Response, error) {
+	address := types.NewAddressByStr(addr.Address)
+	if address == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid account address %s", addr.Address)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &pb.Response{Data: []byte(strconv.FormatUint(b.nonces[address.String()]+1, 10))}, nil
+}
+
+func (b *Broker) GetChainID(context.Context, *pb.Empty) (*pb.Response, error) {
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, b.chainID)
+	return &pb.Response{Data: data}, nil
+}
+
+// execute runs tx on the state of the broker, which a view leaves unchanged.
+func (b *Broker) execute(tx *pb.BxhTransaction, view bool) *pb.Receipt {
+	receipt := &pb.Receipt{TxHash: tx.Hash(), Status: pb.Receipt_SUCCESS}
+	fail := func(format string, args ...interface{}) *pb.Receipt {
+		receipt.Status = pb.Receipt_FAILED
+		receipt.Ret = []byte(fmt.Sprintf(format, args...))
+		return receipt
+	}
+
+	if tx.IBTP != nil {
+		if err := tx.IBTP.CheckServiceID(); err != nil {
+			return fail("invalid ibtp: %s", err)
+		}
+		if !view {
+			b.updateStatus(tx.IBTP)
+		}
+		return receipt
+	}
+
+	data := &pb.TransactionData{}
+	if err := data.Unmarshal(tx.Payload); err != nil {
+		return fail("unmarshal transaction data: %s", err)
+	}
+	switch data.Type {
+	case pb.TransactionData_NORMAL:
+		amount, ok := new(big.Int).SetString(data.Amount, 10)
+		if !ok || amount.Sign() < 0 {
+			return fail("invalid amount %s", data.Amount)
+		}
+		from, to := tx.From.String(), tx.To.String()
+		if b.balanceOf(from).Cmp(amount) < 0 {
+			return fail("not sufficient funds for %s", from)
+		}
+		if !view {
+			b.balances[from] = new(big.Int).Sub(b.balanceOf(from), amount)
+			b.balances[to] = new(big.Int).Add(b.balanceOf(to), amount)
+		}
+		return receipt
+	case pb.TransactionData_INVOKE:
+		if tx.To.String() == (&types.Address{}).String() {
+			// deploy, the contract address is derived from the tx hash
+			address := types.NewAddress(tx.Hash().Bytes()[:types.AddressLength])
+			receipt.Ret = address.Bytes()
+			receipt.ContractAddress = address
+			return receipt
+		}
+		payload := &pb.InvokePayload{}
+		if err := payload.Unmarshal(data.Payload); err != nil {
+			return fail("unmarshal invoke payload: %s", err)
+		}
+		handler, ok := b.contracts[tx.To.String()]
+		if !ok && tx.To.String() == constant.TransactionMgrContractAddr.Address().String() {
+			handler, ok = b.getStatus, true
+		}
+		if !ok {
+			return fail("contract %s doesn't exist", tx.To)
+		}
+		ret, err := handler(tx, payload.Method, payload.Args)
+		if err != nil {
+			return fail("%s", err)
+		}
+		receipt.Ret = ret
+		return receipt
+	default:
+		return fail("unsupported transaction type %s", data.Type)
+	}
+}
+
+// updateStatus moves the interchain transaction of ibtp on, as the transaction
+// manager of BitXHub does on executing it. b.mu must be held.
+func (b *Broker) updateStatus(ibtp *pb.IBTP) {
+	switch ibtp.Type {
+	case pb.IBTP_INTERCHAIN:
+		b.statuses[ibtp.ID()] = pb.TransactionStatus_BEGIN
+	case pb.IBTP_RECEIPT_SUCCESS:
+		b.statuses[ibtp.ID()] = pb.TransactionStatus_SUCCESS
+	case pb.IBTP_RECEIPT_FAILURE:
+		b.statuses[ibtp.ID()] = pb.TransactionStatus_FAILURE
+	case pb.IBTP_RECEIPT_ROLLBACK:
+		b.statuses[ibtp.ID()] = pb.TransactionStatus_ROLLBACK
+	}
+}
+
+// getStatus serves the status of an interchain transaction by the ID of its
+// IBTP. b.mu must be held.
+func (b *Broker) getStatus(_ *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error) {
+	if method != "GetStatus" || len(args) != 1 {
+		return nil, fmt.Errorf("method %s of the transaction manager is not supported", method)
+	}
+	status, ok := b.statuses[string(args[0].Value)]
+	if !ok {
+		return nil, fmt.Errorf("ibtp %s does not exist", args[0].Value)
+	}
+	return []byte(strconv.Itoa(int(status))), nil
+}
+
+// commit commits tx with its receipt in a new block, or the genesis block if tx
+// is nil. b.mu must be held.
+func (b *Broker) commit(tx *pb.BxhTransaction, receipt *pb.Receipt) {
+	header := &pb.BlockHeader{
+		Number:    uint64(len(b.blocks)) + 1,
+		Timestamp: time.Now().UnixNano(),
+	}
+	if len(b.blocks) != 0 {
+		header.ParentHash = b.blocks[len(b.blocks)-1].BlockHash
+	}
+	var (
+		txs      []pb.Transaction
+		wrappers map[string]*pb.InterchainTxWrapper
+	)
+	if tx != nil {
+		txs = []pb.Transaction{tx}
+		var err error
+		header.TxRoot, wrappers, err = buildWrappers(header.Number, []*pb.BxhTransaction{tx}, []*pb.Receipt{receipt})
+		if err != nil {
+			// the merkle tree of a transaction can't fail to build
+			panic(err)
+		}
+	}
+	block := &pb.Block{
+		BlockHeader:  header,
+		Transactions: &pb.Transactions{Transactions: txs},
+		BlockHash:    header.Hash(),
+	}
+	b.blocks = append(b.blocks, block)
+	b.wrappers = append(b.wrappers, wrappers)
+	if tx != nil {
+		hash := tx.Hash().String()
+		b.receipts[hash] = receipt
+		b.txs[hash] = &pb.GetTransactionResponse{
+			Tx: tx,
+			TxMeta: &pb.TransactionMeta{
+				BlockHash:   block.BlockHash.Bytes(),
+				BlockHeight: header.Number,
+			},
+		}
+	}
+
+	close(b.committed)
+	b.committed = make(chan struct{})
+}
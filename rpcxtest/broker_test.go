@@ -0,0 +1,211 @@
+package rpcxtest_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	rpcx "github.com/meshplus/go-bitxhub-client"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, broker *rpcxtest.Broker, opts ...rpcx.Option) (*rpcx.ChainClient, *types.Address) {
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	from, err := key.PublicKey().Address()
+	require.Nil(t, err)
+
+	cli, err := rpcx.NewWithNoGlobalPool(append([]rpcx.Option{
+		rpcx.WithNodesInfo(&rpcx.NodeInfo{Addr: broker.Addr()}),
+		rpcx.WithLogger(logrus.New()),
+		rpcx.WithPrivateKey(key),
+		rpcx.WithReceiptPollInterval(50 * time.Millisecond),
+	}, opts...)...)
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = cli.Stop()
+	})
+	return cli, from
+}
+
+func startBroker(t *testing.T) *rpcxtest.Broker {
+	broker := rpcxtest.NewBroker()
+	require.Nil(t, broker.Start())
+	t.Cleanup(broker.Stop)
+	return broker
+}
+
+func TestBroker_Transfer(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker)
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	broker.SetBalance(from, big.NewInt(100))
+
+	chainID, err := cli.GetChainID()
+	require.Nil(t, err)
+	require.Equal(t, uint64(rpcxtest.DefaultChainID), chainID)
+
+	tx, err := rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(30)).Build()
+	require.Nil(t, err)
+	receipt, err := cli.SendTransactionWithReceipt(tx, nil)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+
+	balance, err := cli.GetBalance(from.String())
+	require.Nil(t, err)
+	require.Equal(t, int64(70), balance.Int64())
+	balance, err = cli.GetBalance(to.String())
+	require.Nil(t, err)
+	require.Equal(t, int64(30), balance.Int64())
+
+	tx, err = rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(100)).Build()
+	require.Nil(t, err)
+	receipt, err = cli.SendTransactionWithReceipt(tx, nil)
+	require.Nil(t, err)
+	require.False(t, receipt.IsSuccess())
+	require.Equal(t, int64(70), broker.Balance(from).Int64())
+
+	// the blocks are chained by their hashes
+	meta, err := cli.GetChainMeta()
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), meta.Height)
+	ch := make(chan *pb.BlockHeader, 3)
+	require.Nil(t, cli.GetBlockHeader(context.Background(), 1, 3, ch))
+	var parent *pb.BlockHeader
+	for header := range ch {
+		if parent != nil {
+			require.Equal(t, parent.Hash().String(), header.ParentHash.String())
+		}
+		parent = header
+	}
+	require.Equal(t, uint64(3), parent.Number)
+	require.Equal(t, meta.BlockHash.String(), parent.Hash().String())
+
+	res, err := cli.GetTransaction(receipt.TxHash.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), res.TxMeta.BlockHeight)
+}
+
+func TestBroker_Nonce(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker)
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	broker.SetBalance(from, big.NewInt(100))
+
+	// a nonce too high keeps the tx pending until the gap is filled
+	tx, err := rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(1)).Build()
+	require.Nil(t, err)
+	queued, err := cli.SendTransaction(tx, &rpcx.TransactOpts{From: from.String(), Nonce: 2})
+	require.Nil(t, err)
+	nonce, err := cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(1), nonce)
+	_, err = cli.GetReceipt(queued)
+	require.ErrorIs(t, err, rpcx.ErrNotFound)
+
+	tx, err = rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(1)).Build()
+	require.Nil(t, err)
+	_, err = cli.SendTransactionWithReceipt(tx, &rpcx.TransactOpts{From: from.String(), Nonce: 1})
+	require.Nil(t, err)
+	receipt, err := cli.WaitForReceipt(queued)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+	nonce, err = cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), nonce)
+
+	// a used nonce is rejected
+	tx, err = rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(1)).Build()
+	require.Nil(t, err)
+	_, err = cli.SendTransaction(tx, &rpcx.TransactOpts{From: from.String(), Nonce: 2})
+	require.ErrorIs(t, err, rpcx.ErrNonceTooLow)
+}
+
+func TestBroker_Invoke(t *testing.T) {
+	broker := startBroker(t)
+	cli, _ := newClient(t, broker)
+	contract := types.NewAddressByStr("0x0000000000000000000000000000000000000010")
+	values := make(map[string]string)
+	broker.HandleInvoke(contract, func(tx *pb.BxhTransaction, method string, args []*pb.Arg) ([]byte, error) {
+		switch method {
+		case "Set":
+			values[string(args[0].Value)] = string(args[1].Value)
+			return nil, nil
+		case "Get":
+			value, ok := values[string(args[0].Value)]
+			if !ok {
+				return nil, fmt.Errorf("key %s doesn't exist", args[0].Value)
+			}
+			return []byte(value), nil
+		default:
+			return nil, fmt.Errorf("unknown method %s", method)
+		}
+	})
+
+	receipt, err := cli.InvokeBVMContract(contract, "Set", nil, rpcx.String("a"), rpcx.String("10"))
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+
+	tx, err := cli.GenerateContractTx(pb.TransactionData_BVM, contract, "Get", rpcx.String("a"))
+	require.Nil(t, err)
+	receipt, err = cli.SendView(tx)
+	require.Nil(t, err)
+	require.Equal(t, "10", string(receipt.Ret))
+
+	receipt, err = cli.InvokeBVMContract(contract, "Get", nil, rpcx.String("b"))
+	require.Nil(t, err)
+	require.False(t, receipt.IsSuccess())
+
+	addr, err := cli.DeployContract([]byte("wasm"), nil)
+	require.Nil(t, err)
+	require.NotEqual(t, (&types.Address{}).String(), addr.String())
+}
+
+func TestBroker_InterchainTxWrappers(t *testing.T) {
+	broker := startBroker(t)
+	cli, _ := newClient(t, broker, rpcx.WithWrapperVerification())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, errC := cli.SubscribeInterchainTxWrappers(ctx, "appchain2")
+	// the subscription starts at the next block
+	time.Sleep(100 * time.Millisecond)
+
+	for index := uint64(1); index <= 2; index++ {
+		receipt, err := cli.SendIBTPCtx(ctx, rpcx.NewIBTPBuilder("1356:appchain1:s1", "1356:appchain2:s2", index).Call("f"), nil)
+		require.Nil(t, err)
+		require.True(t, receipt.IsSuccess())
+	}
+	status, err := cli.GetIBTPStatusCtx(ctx, "1356:appchain1:s1-1356:appchain2:s2-1")
+	require.Nil(t, err)
+	require.Equal(t, pb.TransactionStatus_BEGIN, status)
+
+	for index := uint64(1); index <= 2; index++ {
+		select {
+		case wrappers := <-sub:
+			wrapper := wrappers.InterchainTxWrappers[0]
+			require.Len(t, wrapper.Transactions, 1)
+			require.Equal(t, index, wrapper.Transactions[0].Tx.IBTP.Index)
+		case err := <-errC:
+			require.Nil(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no interchain tx wrappers subscribed")
+		}
+	}
+
+	ch := make(chan *pb.InterchainTxWrappers, 3)
+	require.Nil(t, cli.GetInterchainTxWrappers(ctx, "appchain2", 1, 3, ch))
+	var n int
+	for wrappers := range ch {
+		n += len(wrappers.InterchainTxWrappers[0].Transactions)
+	}
+	require.Equal(t, 2, n)
+}
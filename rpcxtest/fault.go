@@ -0,0 +1,200 @@
+package rpcxtest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fault is a failure injected into the calls of an RPC of the broker, such as
+//
+//	// two sends fail as if the node were down
+//	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Unavailable, Times: 2})
+//	// receipts are served slower than rpcx.GetReceiptTimeout
+//	broker.InjectFault("GetReceipt", rpcxtest.Fault{Delay: 3 * time.Second})
+//	// subscriptions break after the first block
+//	broker.InjectFault("Subscribe", rpcxtest.Fault{Code: codes.Unavailable, After: 1})
+//	// a tx is accepted, but the client sees a failure
+//	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Unavailable, Lost: true, Times: 1})
+type Fault struct {
+	// Code is the gRPC code the call fails with, none if codes.OK.
+	Code codes.Code
+	// Message is the message of the status error, the name of Code if empty.
+	Message string
+	// Delay delays the call, or fails it with codes.DeadlineExceeded if the
+	// context of the call ends first.
+	Delay time.Duration
+	// After lets a stream send After messages before the fault breaks it. It
+	// has no effect on unary calls.
+	After int
+	// Times is the number of calls the fault is injected into, all calls if 0.
+	Times int
+	// Lost lets a unary call be handled before it fails, as if its response
+	// were lost.
+	Lost bool
+}
+
+func (f *Fault) err() error {
+	msg := f.Message
+	if msg == "" {
+		msg = "injected " + f.Code.String()
+	}
+	return status.Error(f.Code, msg)
+}
+
+// InjectFault injects fault into the calls of method, named as in the
+// ChainBroker service, like "SendTransaction". It replaces the fault injected
+// into method before.
+func (b *Broker) InjectFault(method string, fault Fault) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.faults[method] = &fault
+}
+
+// ClearFaults removes all injected faults.
+func (b *Broker) ClearFaults() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.faults = make(map[string]*Fault)
+}
+
+// DropStreams breaks all open streams with codes.Unavailable, as when the
+// connection to a node is lost.
+func (b *Broker) DropStreams() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, drop := range b.streams {
+		drop()
+	}
+	b.streams = make(map[int]context.CancelFunc)
+}
+
+// SetCommitDelay commits the transactions sent afterwards delay after they are
+// accepted, so that their receipts show up late. The pending nonce of the
+// sender counts them as soon as they are accepted.
+func (b *Broker) SetCommitDelay(delay time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.commitDelay = delay
+}
+
+// SetNonce sets the nonce of the latest transaction of address, so that the
+// next transaction must have nonce+1, as if the account sent transactions
+// through another client.
+func (b *Broker) SetNonce(address *types.Address, nonce uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nonces[address.String()] = nonce
+}
+
+// takeFault returns the fault injected into the call of fullMethod, counting
+// the call against the times of the fault.
+func (b *Broker) takeFault(fullMethod string) *Fault {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fault, ok := b.faults[method]
+	if !ok {
+		return nil
+	}
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			delete(b.faults, method)
+		}
+	}
+	f := *fault
+	return &f
+}
+
+func delay(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (b *Broker) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if fault := b.takeFault(info.FullMethod); fault != nil {
+		if err := delay(ctx, fault.Delay); err != nil {
+			return nil, err
+		}
+		if fault.Code != codes.OK {
+			if fault.Lost {
+				_, _ = handler(ctx, req)
+			}
+			return nil, fault.err()
+		}
+	}
+	return handler(ctx, req)
+}
+
+func (b *Broker) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	fault := b.takeFault(info.FullMethod)
+	if fault != nil {
+		if err := delay(ss.Context(), fault.Delay); err != nil {
+			return err
+		}
+		if fault.Code != codes.OK && fault.After == 0 {
+			return fault.err()
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	b.mu.Lock()
+	b.streamID++
+	id := b.streamID
+	b.streams[id] = cancel
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.streams, id)
+		b.mu.Unlock()
+	}()
+
+	stream := &faultStream{ServerStream: ss, ctx: ctx}
+	if fault != nil && fault.Code != codes.OK {
+		stream.fault = fault
+	}
+	err := handler(srv, stream)
+	if err == nil && ctx.Err() != nil && ss.Context().Err() == nil {
+		return status.Error(codes.Unavailable, "stream is dropped")
+	}
+	return err
+}
+
+// faultStream breaks with its fault after fault.After messages.
+type faultStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	fault *Fault
+	sent  int
+}
+
+func (s *faultStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *faultStream) SendMsg(m interface{}) error {
+	if s.ctx.Err() != nil {
+		return status.Error(codes.Unavailable, "stream is dropped")
+	}
+	if s.fault != nil && s.sent >= s.fault.After {
+		return s.fault.err()
+	}
+	s.sent++
+	return s.ServerStream.SendMsg(m)
+}
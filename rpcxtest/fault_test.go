@@ -0,0 +1,135 @@
+package rpcxtest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	rpcx "github.com/meshplus/go-bitxhub-client"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func sendTransfer(cli *rpcx.ChainClient, from *types.Address) (string, error) {
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	tx, err := rpcx.NewTxBuilder(from).Transfer(to, big.NewInt(1)).Build()
+	if err != nil {
+		return "", err
+	}
+	return cli.SendTransaction(tx, nil)
+}
+
+func TestBroker_InjectFault(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker)
+	broker.SetBalance(from, big.NewInt(100))
+
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Internal, Times: 1})
+	_, err := sendTransfer(cli, from)
+	require.ErrorIs(t, err, rpcx.ErrBrokenNetwork)
+
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.InvalidArgument, Message: "bad tx", Times: 1})
+	_, err = sendTransfer(cli, from)
+	require.ErrorIs(t, err, rpcx.ErrReconstruct)
+	require.Contains(t, err.Error(), "bad tx")
+
+	broker.InjectFault("SendTransaction", rpcxtest.Fault{Code: codes.Unavailable})
+	for i := 0; i < 2; i++ {
+		_, err = sendTransfer(cli, from)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	broker.ClearFaults()
+	_, err = sendTransfer(cli, from)
+	require.Nil(t, err)
+
+	// a call slower than its deadline fails
+	broker.InjectFault("GetChainID", rpcxtest.Fault{Delay: time.Second, Times: 1})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = cli.GetChainIDCtx(ctx)
+	require.ErrorIs(t, err, rpcx.ErrBrokenNetwork)
+	_, err = cli.GetChainID()
+	require.Nil(t, err)
+}
+
+func TestBroker_NonceConflict(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker, rpcx.WithNonceManager())
+	broker.SetBalance(from, big.NewInt(100))
+
+	_, err := sendTransfer(cli, from)
+	require.Nil(t, err)
+
+	// another client sent transactions of the account
+	broker.SetNonce(from, 5)
+	_, err = sendTransfer(cli, from)
+	require.ErrorIs(t, err, rpcx.ErrNonceTooLow)
+	var rpcErr *rpcx.RPCError
+	require.True(t, errors.As(err, &rpcErr))
+	require.Equal(t, "SendTransaction", rpcErr.Method)
+	require.Equal(t, broker.Addr(), rpcErr.Node)
+
+	// the nonce manager is resynced by the failure
+	_, err = sendTransfer(cli, from)
+	require.Nil(t, err)
+	nonce, err := cli.GetPendingNonceByAccount(from.String())
+	require.Nil(t, err)
+	require.Equal(t, uint64(7), nonce)
+}
+
+func TestBroker_CommitDelay(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker)
+	broker.SetBalance(from, big.NewInt(100))
+	broker.SetCommitDelay(time.Second)
+
+	start := time.Now()
+	hash, err := sendTransfer(cli, from)
+	require.Nil(t, err)
+	_, err = cli.GetTransaction(hash)
+	require.NotNil(t, err)
+	receipt, err := cli.WaitForReceiptCtx(context.Background(), hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestBroker_BrokenStreams(t *testing.T) {
+	broker := startBroker(t)
+	cli, from := newClient(t, broker)
+	broker.SetBalance(from, big.NewInt(100))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the subscription breaks after a block
+	broker.InjectFault("Subscribe", rpcxtest.Fault{Code: codes.Unavailable, After: 1, Times: 1})
+	blocks, errC := cli.SubscribeBlocks(ctx)
+	time.Sleep(100 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		_, err := sendTransfer(cli, from)
+		require.Nil(t, err)
+	}
+	var n int
+	for range blocks {
+		n++
+	}
+	require.Equal(t, 1, n)
+	require.ErrorIs(t, <-errC, rpcx.ErrBrokenNetwork)
+
+	// a dropped stream ends before its last block
+	headers := make(chan *pb.BlockHeader, 10)
+	require.Nil(t, cli.GetBlockHeader(ctx, 1, 100, headers))
+	time.Sleep(100 * time.Millisecond)
+	broker.DropStreams()
+	n = 0
+	for range headers {
+		n++
+	}
+	require.Equal(t, int(broker.Height()), n)
+}
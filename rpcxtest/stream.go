@@ -0,0 +1,172 @@
+package rpcxtest
+
+import (
+	"sort"
+
+	"github.com/cbergoon/merkletree"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// buildWrappers groups the IBTPs of txs by the chain ID of their destination,
+// with the other transactions in a group of their own. The merkle root of each
+// group is an L2 root, and the merkle root of the L2 roots is the TxRoot of the
+// block, so the wrappers pass rpcx.VerifyInterchainTxWrapper.
+func buildWrappers(height uint64, txs []*pb.BxhTransaction, receipts []*pb.Receipt) (*types.Hash, map[string]*pb.InterchainTxWrapper, error) {
+	groups := make(map[string][]*pb.VerifiedTx)
+	for i, tx := range txs {
+		pid := ""
+		if tx.IBTP != nil {
+			_, pid, _ = tx.IBTP.ParseTo()
+		}
+		groups[pid] = append(groups[pid], &pb.VerifiedTx{
+			Tx:    tx,
+			Valid: receipts[i].IsSuccess(),
+		})
+	}
+	pids := make([]string, 0, len(groups))
+	for pid := range groups {
+		pids = append(pids, pid)
+	}
+	sort.Strings(pids)
+
+	l2Roots := make([]types.Hash, 0, len(pids))
+	for _, pid := range pids {
+		contents := make([]merkletree.Content, 0, len(groups[pid]))
+		for _, tx := range groups[pid] {
+			contents = append(contents, tx)
+		}
+		root, err := merkleRoot(contents)
+		if err != nil {
+			return nil, nil, err
+		}
+		l2Roots = append(l2Roots, *types.NewHash(root))
+	}
+	contents := make([]merkletree.Content, 0, len(l2Roots))
+	for i := range l2Roots {
+		contents = append(contents, &l2Roots[i])
+	}
+	txRoot, err := merkleRoot(contents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrappers := make(map[string]*pb.InterchainTxWrapper)
+	for _, pid := range pids {
+		if pid == "" {
+			continue
+		}
+		wrappers[pid] = &pb.InterchainTxWrapper{
+			L2Roots:      l2Roots,
+			Transactions: groups[pid],
+			Height:       height,
+		}
+	}
+	return types.NewHash(txRoot), wrappers, nil
+}
+
+func merkleRoot(contents []merkletree.Content) ([]byte, error) {
+	tree, err := merkletree.NewTree(contents)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MerkleRoot(), nil
+}
+
+// wrappersAt returns the interchain tx wrappers of pid at height, with an empty
+// wrapper if the block has no IBTP for pid. b.mu must be held.
+func (b *Broker) wrappersAt(height uint64, pid string) *pb.InterchainTxWrappers {
+	wrapper, ok := b.wrappers[height-1][pid]
+	if !ok {
+		wrapper = &pb.InterchainTxWrapper{Height: height}
+	}
+	return &pb.InterchainTxWrappers{InterchainTxWrappers: []*pb.InterchainTxWrapper{wrapper}}
+}
+
+// streamBlocks calls send for each block from begin to end, waiting for the
+// blocks not committed yet, until send fails or the stream is closed. end 0
+// streams forever.
+func (b *Broker) streamBlocks(stream grpc.ServerStream, begin, end uint64, send func(height uint64) error) error {
+	if begin == 0 {
+		begin = 1
+	}
+	for height := begin; end == 0 || height <= end; {
+		b.mu.Lock()
+		latest := uint64(len(b.blocks))
+		committed := b.committed
+		b.mu.Unlock()
+
+		for ; height <= latest && (end == 0 || height <= end); height++ {
+			if err := send(height); err != nil {
+				return err
+			}
+		}
+		if end != 0 && height > end {
+			return nil
+		}
+		select {
+		case <-committed:
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+func (b *Broker) GetBlockHeader(req *pb.GetBlockHeaderRequest, srv pb.ChainBroker_GetBlockHeaderServer) error {
+	if req.End < req.Begin {
+		return status.Errorf(codes.InvalidArgument, "invalid block range [%d, %d]", req.Begin, req.End)
+	}
+	return b.streamBlocks(srv, req.Begin, req.End, func(height uint64) error {
+		b.mu.Lock()
+		header := b.blocks[height-1].BlockHeader
+		b.mu.Unlock()
+		return srv.Send(header)
+	})
+}
+
+func (b *Broker) GetInterchainTxWrappers(req *pb.GetInterchainTxWrappersRequest, srv pb.ChainBroker_GetInterchainTxWrappersServer) error {
+	if req.End < req.Begin {
+		return status.Errorf(codes.InvalidArgument, "invalid block range [%d, %d]", req.Begin, req.End)
+	}
+	return b.streamBlocks(srv, req.Begin, req.End, func(height uint64) error {
+		b.mu.Lock()
+		wrappers := b.wrappersAt(height, req.Pid)
+		b.mu.Unlock()
+		return srv.Send(wrappers)
+	})
+}
+
+// Subscribe streams the blocks, block headers or interchain tx wrappers of the
+// appchain in req.Extra committed after the subscription.
+func (b *Broker) Subscribe(req *pb.SubscriptionRequest, srv pb.ChainBroker_SubscribeServer) error {
+	type marshaler interface {
+		Marshal() ([]byte, error)
+	}
+	var get func(height uint64) marshaler
+	switch req.Type {
+	case pb.SubscriptionRequest_BLOCK:
+		get = func(height uint64) marshaler { return b.blocks[height-1] }
+	case pb.SubscriptionRequest_BLOCK_HEADER:
+		get = func(height uint64) marshaler { return b.blocks[height-1].BlockHeader }
+	case pb.SubscriptionRequest_INTERCHAIN_TX_WRAPPER:
+		pid := string(req.Extra)
+		get = func(height uint64) marshaler { return b.wrappersAt(height, pid) }
+	default:
+		return status.Errorf(codes.Unimplemented, "subscription type %s is not supported", req.Type)
+	}
+
+	begin := b.Height() + 1
+	return b.streamBlocks(srv, begin, 0, func(height uint64) error {
+		b.mu.Lock()
+		data, err := get(height).Marshal()
+		b.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		return srv.Send(&pb.Response{Data: data})
+	})
+}
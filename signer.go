@@ -0,0 +1,85 @@
+package rpcx
+
+import (
+	"fmt"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// Signer signs on behalf of a BitXHub account, so that the client doesn't have
+// to hold the private key of the account itself. The signature must be prefixed
+// with the key type, as produced by asym.SignWithType.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() *types.Address
+
+	// Sign signs digest with the private key of the account.
+	Sign(digest []byte) ([]byte, error)
+}
+
+// KeySigner signs with a private key held in memory.
+type KeySigner struct {
+	key     crypto.PrivateKey
+	address *types.Address
+}
+
+var _ Signer = (*KeySigner)(nil)
+
+func NewKeySigner(key crypto.PrivateKey) (*KeySigner, error) {
+	if key == nil {
+		return nil, fmt.Errorf("private key is empty")
+	}
+	address, err := key.PublicKey().Address()
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{key: key, address: address}, nil
+}
+
+// NewKeystoreSigner loads the private key of a bitxhub-kit keystore file,
+// encrypted with password.
+func NewKeystoreSigner(path, password string) (*KeySigner, error) {
+	key, err := asym.RestorePrivateKey(path, password)
+	if err != nil {
+		return nil, fmt.Errorf("restore private key from %s: %w", path, err)
+	}
+	return NewKeySigner(key)
+}
+
+// Address returns a copy of the address of the key, as types.Address caches
+// its string form and isn't safe to share between goroutines.
+func (signer *KeySigner) Address() *types.Address {
+	return types.NewAddress(signer.address.Bytes())
+}
+
+func (signer *KeySigner) Sign(digest []byte) ([]byte, error) {
+	return asym.SignWithType(signer.key, digest)
+}
+
+// signTx signs tx with signer, as tx.Sign does with a private key.
+func signTx(tx *pb.BxhTransaction, signer Signer) error {
+	sig, err := signer.Sign(tx.SignHash().Bytes())
+	if err != nil {
+		return err
+	}
+	tx.Signature = sig
+	return nil
+}
+
+// signerOf returns the signer of a transaction sent with opts: the signer or
+// the private key of opts if set, otherwise the signer of the client.
+func (cli *ChainClient) signerOf(opts *TransactOpts) (Signer, error) {
+	switch {
+	case opts == nil:
+		return cli.currentSigner(), nil
+	case opts.Signer != nil:
+		return opts.Signer, nil
+	case opts.PrivKey != nil:
+		return NewKeySigner(opts.PrivKey)
+	default:
+		return cli.currentSigner(), nil
+	}
+}
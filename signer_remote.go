@@ -0,0 +1,207 @@
+package rpcx
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+)
+
+// remoteSignRequest is a line sent to a remote signer, and remoteSignResponse
+// the line it answers with.
+type remoteSignRequest struct {
+	Method string `json:"method"`
+	Digest []byte `json:"digest,omitempty"`
+}
+
+type remoteSignResponse struct {
+	Address   string `json:"address,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner signs by a signer served by ServeSigner in another process over
+// a unix socket, so that the key stays out of the process of the client. The
+// protocol has no authentication of its own, access to the signer is granted
+// by the file permissions of the socket. Each request is a JSON line answered
+// with a JSON line:
+//
+//	{"method":"address"}              {"address":"0x..."}
+//	{"method":"sign","digest":"..."}  {"signature":"..."}
+//
+// Byte fields are base64 encoded, and failures come back in "error".
+type RemoteSigner struct {
+	addr    string
+	timeout time.Duration
+	address *types.Address
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// NewRemoteSigner connects to the signer at the unix socket addr and fetches
+// the address it signs for. Each request must be answered within timeout.
+func NewRemoteSigner(addr string, timeout time.Duration) (*RemoteSigner, error) {
+	signer := &RemoteSigner{
+		addr:    addr,
+		timeout: timeout,
+	}
+	resp, err := signer.call(&remoteSignRequest{Method: "address"})
+	if err != nil {
+		return nil, err
+	}
+	address := types.NewAddressByStr(resp.Address)
+	if address == nil {
+		return nil, fmt.Errorf("remote signer returns invalid address %q", resp.Address)
+	}
+	signer.address = address
+	return signer, nil
+}
+
+func (signer *RemoteSigner) Address() *types.Address {
+	return types.NewAddress(signer.address.Bytes())
+}
+
+// Sign signs digest by the remote signer, and checks that the signature is
+// made by the key of its address.
+func (signer *RemoteSigner) Sign(digest []byte) ([]byte, error) {
+	resp, err := signer.call(&remoteSignRequest{Method: "sign", Digest: digest})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Signature) == 0 {
+		return nil, fmt.Errorf("remote signer returns empty signature")
+	}
+	if ok, err := asym.VerifyWithType(resp.Signature, digest, *signer.Address()); err != nil || !ok {
+		return nil, fmt.Errorf("remote signer returns a signature not made by %s", signer.address.String())
+	}
+	return resp.Signature, nil
+}
+
+// Close closes the connection to the remote signer. It is reopened by the next
+// request.
+func (signer *RemoteSigner) Close() error {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	return signer.closeConn()
+}
+
+func (signer *RemoteSigner) closeConn() error {
+	if signer.conn == nil {
+		return nil
+	}
+	err := signer.conn.Close()
+	signer.conn = nil
+	signer.reader = nil
+	return err
+}
+
+func (signer *RemoteSigner) call(req *remoteSignRequest) (*remoteSignResponse, error) {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+
+	if signer.conn == nil {
+		conn, err := net.DialTimeout("unix", signer.addr, signer.timeout)
+		if err != nil {
+			return nil, fmt.Errorf("dial remote signer %s: %w", signer.addr, err)
+		}
+		signer.conn = conn
+		signer.reader = bufio.NewReader(conn)
+	}
+
+	resp, err := signer.roundTrip(req)
+	if err != nil {
+		// the connection is out of step after a failed round trip
+		_ = signer.closeConn()
+		return nil, fmt.Errorf("remote signer %s: %w", signer.addr, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("remote signer %s: %s", signer.addr, resp.Error)
+	}
+	return resp, nil
+}
+
+func (signer *RemoteSigner) roundTrip(req *remoteSignRequest) (*remoteSignResponse, error) {
+	if signer.timeout > 0 {
+		if err := signer.conn.SetDeadline(time.Now().Add(signer.timeout)); err != nil {
+			return nil, err
+		}
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := signer.conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := signer.reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := &remoteSignResponse{}
+	if err := json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ServeSigner serves signer to RemoteSigners connecting on l, until l is
+// closed. l must listen on a unix socket, as anyone able to connect can sign
+// with signer.
+func ServeSigner(l net.Listener, signer Signer) error {
+	if network := l.Addr().Network(); network != "unix" {
+		return fmt.Errorf("remote signer must be served on a unix socket, not %s", network)
+	}
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveSignerConn(conn, signer)
+	}
+}
+
+func serveSignerConn(conn net.Conn, signer Signer) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	encoder := json.NewEncoder(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		req := &remoteSignRequest{}
+		resp := &remoteSignResponse{}
+		if err := json.Unmarshal(line, req); err != nil {
+			resp.Error = fmt.Sprintf("invalid request: %s", err)
+		} else {
+			switch req.Method {
+			case "address":
+				resp.Address = signer.Address().String()
+			case "sign":
+				sig, err := signer.Sign(req.Digest)
+				if err != nil {
+					resp.Error = err.Error()
+				}
+				resp.Signature = sig
+			default:
+				resp.Error = fmt.Sprintf("unknown method %q", req.Method)
+			}
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package rpcx
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func serveTestSigner(t *testing.T, signer Signer) string {
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", path)
+	require.Nil(t, err)
+	go func() {
+		_ = ServeSigner(l, signer)
+	}()
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+	return path
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	keySigner, err := NewKeySigner(key)
+	require.Nil(t, err)
+
+	remote, err := NewRemoteSigner(serveTestSigner(t, keySigner), time.Second)
+	require.Nil(t, err)
+	defer remote.Close()
+	require.Equal(t, keySigner.Address().String(), remote.Address().String())
+	*remote.Address() = types.Address{}
+	require.Equal(t, keySigner.Address().String(), remote.Address().String())
+
+	// the remote signer signs in place of the key, and reconnects when closed
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	for i := 0; i < 2; i++ {
+		tx, err := NewTxBuilder(nil).Transfer(to, big.NewInt(1)).SignWith(remote).Build()
+		require.Nil(t, err)
+		require.Equal(t, keySigner.Address().String(), tx.From.String())
+		require.Nil(t, tx.VerifySignature())
+		require.Nil(t, remote.Close())
+	}
+
+	_, err = NewRemoteSigner(filepath.Join(t.TempDir(), "none.sock"), time.Second)
+	require.NotNil(t, err)
+
+	// only a unix socket is served, as the protocol has no authentication
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer l.Close()
+	require.NotNil(t, ServeSigner(l, keySigner))
+}
+
+// forgingSigner claims the address of a key it doesn't sign with.
+type forgingSigner struct {
+	*KeySigner
+	address *types.Address
+}
+
+func (signer *forgingSigner) Address() *types.Address {
+	return signer.address
+}
+
+func TestRemoteSigner_WrongSignature(t *testing.T) {
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	keySigner, err := NewKeySigner(key)
+	require.Nil(t, err)
+	other, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	claimed, err := other.PublicKey().Address()
+	require.Nil(t, err)
+
+	remote, err := NewRemoteSigner(serveTestSigner(t, &forgingSigner{KeySigner: keySigner, address: claimed}), time.Second)
+	require.Nil(t, err)
+	defer remote.Close()
+	_, err = remote.Sign(make([]byte, 32))
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), claimed.String())
+}
+
+func TestChainClient_SetSigner(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	ctx := context.Background()
+
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	keySigner, err := NewKeySigner(key)
+	require.Nil(t, err)
+	remote, err := NewRemoteSigner(serveTestSigner(t, keySigner), time.Second)
+	require.Nil(t, err)
+	defer remote.Close()
+	cli.SetSigner(remote)
+	broker.SetBalance(remote.Address(), big.NewInt(1))
+
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+	tx, err := NewTxBuilder(remote.Address()).Transfer(to, big.NewInt(1)).Build()
+	require.Nil(t, err)
+	hash, err := cli.SendTransactionCtx(ctx, tx, nil)
+	require.Nil(t, err)
+	receipt, err := cli.WaitForReceiptCtx(ctx, hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+
+	// the signer of opts takes precedence over the one of the client
+	other, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	otherSigner, err := NewKeySigner(other)
+	require.Nil(t, err)
+	broker.SetBalance(otherSigner.Address(), big.NewInt(1))
+	tx, err = NewTxBuilder(otherSigner.Address()).Transfer(to, big.NewInt(1)).Build()
+	require.Nil(t, err)
+	hash, err = cli.SendTransactionCtx(ctx, tx, &TransactOpts{From: otherSigner.Address().String(), Signer: otherSigner})
+	require.Nil(t, err)
+	receipt, err = cli.WaitForReceiptCtx(ctx, hash)
+	require.Nil(t, err)
+	require.True(t, receipt.IsSuccess())
+
+	// the signer can be replaced while transactions are being built
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			cli.SetSigner(keySigner)
+			cli.SetPrivateKey(other)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := cli.GenerateContractTx(pb.TransactionData_BVM, to, "f")
+		require.Nil(t, err)
+	}
+	<-done
+}
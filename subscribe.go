@@ -3,102 +3,235 @@ package rpcx
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/meshplus/bitxhub-model/pb"
 	_ "github.com/meshplus/eth-kit/types"
 )
 
 func (cli *ChainClient) Subscribe(ctx context.Context, typ pb.SubscriptionRequest_Type, extra []byte) (<-chan interface{}, error) {
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	grpcClient, err := cli.pool.getClient()
+	subClient, err := cli.subscribe(ctx, typ, extra)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
+
+	c, errC := pumpStream(ctx, cli.metrics, subscriptionType(typ), subClient.Recv, func(data []byte) (interface{}, error) {
+		ret, err := decodeSubscription(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		if wrappers, ok := ret.(*pb.InterchainTxWrappers); ok && cli.verifyWrappers {
+			if err := cli.VerifyInterchainTxWrappersCtx(ctx, wrappers); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	})
+	go func() {
+		for err := range errC {
+			cli.logger.Errorf("receive: %v", err)
 		}
 	}()
+
+	return c, nil
+}
+
+// SubscribeBlocks subscribes to new blocks. Stream and decoding errors are
+// delivered on the error channel, after which both channels are closed.
+func (cli *ChainClient) SubscribeBlocks(ctx context.Context) (<-chan *pb.Block, <-chan error) {
+	return subscribeTyped(ctx, cli, pb.SubscriptionRequest_BLOCK, nil, decodeMessage[pb.Block])
+}
+
+// SubscribeBlockHeaders subscribes to new block headers.
+func (cli *ChainClient) SubscribeBlockHeaders(ctx context.Context) (<-chan *pb.BlockHeader, <-chan error) {
+	return subscribeTyped(ctx, cli, pb.SubscriptionRequest_BLOCK_HEADER, nil, decodeMessage[pb.BlockHeader])
+}
+
+// SubscribeEvents subscribes to contract events.
+func (cli *ChainClient) SubscribeEvents(ctx context.Context) (<-chan *pb.Event, <-chan error) {
+	return subscribeTyped(ctx, cli, pb.SubscriptionRequest_EVENT, nil, decodeMessage[pb.Event])
+}
+
+// SubscribeInterchainTx subscribes to interchain transactions.
+func (cli *ChainClient) SubscribeInterchainTx(ctx context.Context) (<-chan *pb.IBTP, <-chan error) {
+	return subscribeTyped(ctx, cli, pb.SubscriptionRequest_INTERCHAIN_TX, nil, decodeMessage[pb.IBTP])
+}
+
+// SubscribeInterchainTxWrappers subscribes to the interchain tx wrappers of the appchain pid.
+// With WithWrapperVerification, a wrapper failing verification ends the subscription
+// with an error wrapping ErrInvalidWrapper.
+func (cli *ChainClient) SubscribeInterchainTxWrappers(ctx context.Context, pid string) (<-chan *pb.InterchainTxWrappers, <-chan error) {
+	return subscribeTyped(ctx, cli, pb.SubscriptionRequest_INTERCHAIN_TX_WRAPPER, []byte(pid), func(data []byte) (*pb.InterchainTxWrappers, error) {
+		wrappers, err := decodeMessage[pb.InterchainTxWrappers](data)
+		if err != nil {
+			return nil, err
+		}
+		if cli.verifyWrappers {
+			if err := cli.VerifyInterchainTxWrappersCtx(ctx, wrappers); err != nil {
+				return nil, err
+			}
+		}
+		return wrappers, nil
+	})
+}
+
+func (cli *ChainClient) subscribe(ctx context.Context, typ pb.SubscriptionRequest_Type, extra []byte) (pb.ChainBroker_SubscribeClient, error) {
 	req := &pb.SubscriptionRequest{
 		Type:  typ,
 		Extra: extra,
 	}
 
-	subClient, err := grpcClient.broker.Subscribe(ctx, req)
+	var stream *subscribeStream
+	err := cli.openStream(ctx, "Subscribe", func(ctx context.Context, client *grpcClient) error {
+		subClient, err := client.broker.Subscribe(ctx, req)
+		if err != nil {
+			return err
+		}
+		stream = &subscribeStream{ChainBroker_SubscribeClient: subClient, client: client}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
+	return stream, nil
+}
+
+// subscribeStream classifies the errors breaking a subscription.
+type subscribeStream struct {
+	pb.ChainBroker_SubscribeClient
+	client *grpcClient
+}
+
+func (s *subscribeStream) Recv() (*pb.Response, error) {
+	resp, err := s.ChainBroker_SubscribeClient.Recv()
+	if err != nil && err != io.EOF {
+		return nil, s.client.rpcError("Subscribe", err)
+	}
+	return resp, err
+}
 
-	c := make(chan interface{})
+func subscribeTyped[T any](ctx context.Context, cli *ChainClient, typ pb.SubscriptionRequest_Type, extra []byte,
+	decode func([]byte) (T, error)) (<-chan T, <-chan error) {
+	subClient, err := cli.subscribe(ctx, typ, extra)
+	if err != nil {
+		return failedStream[T](err)
+	}
+	return pumpStream(ctx, cli.metrics, subscriptionType(typ), subClient.Recv, decode)
+}
+
+// subscriptionType is the type of a subscription in metrics, like "block".
+func subscriptionType(typ fmt.Stringer) string {
+	return strings.ToLower(typ.String())
+}
+
+// pumpStream receives and decodes stream responses until the stream ends, ctx
+// is done, or an error occurs. The error, if any, is buffered on the error
+// channel before the data channel is closed. The subscription is reported to
+// metrics as of type typ.
+func pumpStream[T any](ctx context.Context, metrics Metrics, typ string, recv func() (*pb.Response, error), decode func([]byte) (T, error)) (<-chan T, <-chan error) {
+	c := make(chan T)
+	errC := make(chan error, 1)
+	metrics.SubscriptionOpened(typ)
 	go func() {
+		defer metrics.SubscriptionClosed(typ)
+		defer close(errC)
 		defer close(c)
 		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				resp, err := subClient.Recv()
-				if err != nil {
-					cli.logger.Errorf("receive: %v", err)
-					return
+			resp, err := recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					errC <- err
 				}
+				return
+			}
+			metrics.MessageReceived(typ)
 
-				var ret interface{}
-				switch typ {
-				case pb.SubscriptionRequest_BLOCK_HEADER:
-					header := &pb.BlockHeader{}
-					if err := header.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive header error: %v", err)
-						return
-					}
-					ret = header
-				case pb.SubscriptionRequest_BLOCK:
-					block := &pb.Block{}
-					if err := block.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive header error: %v", err)
-						return
-					}
-					ret = block
-				case pb.SubscriptionRequest_EVENT:
-					event := &pb.Event{}
-					if err := event.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive event error: %v", err)
-						return
-					}
-					ret = event
-				case pb.SubscriptionRequest_INTERCHAIN_TX:
-					ibtp := &pb.IBTP{}
-					if err := ibtp.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive interchain tx error: %v", err)
-						return
-					}
-					ret = ibtp
-				case pb.SubscriptionRequest_INTERCHAIN_TX_WRAPPER:
-					wrapper := &pb.InterchainTxWrappers{}
-					if err := wrapper.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive interchain tx wrapper error: %v", err)
-						return
-					}
-					ret = wrapper
-				case pb.SubscriptionRequest_UNION_INTERCHAIN_TX_WRAPPER:
-					wrapper := &pb.InterchainTxWrappers{}
-					if err := wrapper.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive interchain tx wrapper error: %v", err)
-						return
-					}
-					ret = wrapper
-				default:
-					ret = resp.Data
-				}
+			msg, err := decode(resp.Data)
+			if err != nil {
+				errC <- fmt.Errorf("decode subscription data: %w", err)
+				return
+			}
 
-				c <- ret
+			select {
+			case c <- msg:
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
+	return c, errC
+}
 
-	return c, nil
+func failedStream[T any](err error) (<-chan T, <-chan error) {
+	c := make(chan T)
+	errC := make(chan error, 1)
+	errC <- err
+	close(c)
+	close(errC)
+	return c, errC
+}
+
+// decodeMessage unmarshals data into a new message of type T.
+func decodeMessage[T any, PT interface {
+	*T
+	Unmarshal([]byte) error
+}](data []byte) (PT, error) {
+	msg := PT(new(T))
+	if err := msg.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func decodeSubscription(typ pb.SubscriptionRequest_Type, data []byte) (interface{}, error) {
+	switch typ {
+	case pb.SubscriptionRequest_BLOCK_HEADER:
+		return decodeMessage[pb.BlockHeader](data)
+	case pb.SubscriptionRequest_BLOCK:
+		return decodeMessage[pb.Block](data)
+	case pb.SubscriptionRequest_EVENT:
+		return decodeMessage[pb.Event](data)
+	case pb.SubscriptionRequest_INTERCHAIN_TX:
+		return decodeMessage[pb.IBTP](data)
+	case pb.SubscriptionRequest_INTERCHAIN_TX_WRAPPER, pb.SubscriptionRequest_UNION_INTERCHAIN_TX_WRAPPER:
+		return decodeMessage[pb.InterchainTxWrappers](data)
+	default:
+		return data, nil
+	}
+}
+
+// waitOnBlocks calls done until it reports true, which is when waitOnBlocks
+// returns. done is called again on every new block header, and every interval
+// in case block headers can't be subscribed. Errors of done are logged only.
+func (cli *ChainClient) waitOnBlocks(ctx context.Context, what string, interval time.Duration, done func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	headers, errC := cli.SubscribeBlockHeaders(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := done(ctx)
+		if err != nil {
+			cli.logger.Warningf("check %s: %s", what, err)
+		} else if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait %s: %w", what, ctx.Err())
+		case _, ok := <-headers:
+			if !ok {
+				if err := <-errC; err != nil {
+					cli.logger.Warningf("block subscription to wait %s: %s, fall back to polling", what, err)
+				}
+				headers = nil
+			}
+		case <-ticker.C:
+		}
+	}
 }
@@ -2,73 +2,78 @@ package rpcx
 
 import (
 	"context"
-	"fmt"
+	"io"
 
 	"github.com/meshplus/bitxhub-model/pb"
 )
 
 func (cli *ChainClient) SubscribeAudit(ctx context.Context, typ pb.AuditSubscriptionRequest_Type, blockHeight uint64, extra []byte) (<-chan interface{}, error) {
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	grpcClient, err := cli.pool.getClient()
+	subClient, err := cli.subscribeAudit(ctx, typ, blockHeight, extra)
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
+
+	c, errC := pumpStream(ctx, cli.metrics, subscriptionType(typ), subClient.Recv, func(data []byte) (interface{}, error) {
+		switch typ {
+		case pb.AuditSubscriptionRequest_AUDIT_NODE:
+			return decodeMessage[pb.AuditTxInfo](data)
+		default:
+			return data, nil
+		}
+	})
+	go func() {
+		for err := range errC {
+			cli.logger.Error("receive: ", err)
 		}
 	}()
-	from, err := cli.privateKey.PublicKey().Address()
+
+	return c, nil
+}
+
+// SubscribeAuditTxInfo subscribes to the audit info of the client's audit node
+// from blockHeight on. Stream and decoding errors are delivered on the error
+// channel, after which both channels are closed.
+func (cli *ChainClient) SubscribeAuditTxInfo(ctx context.Context, blockHeight uint64) (<-chan *pb.AuditTxInfo, <-chan error) {
+	subClient, err := cli.subscribeAudit(ctx, pb.AuditSubscriptionRequest_AUDIT_NODE, blockHeight, nil)
 	if err != nil {
-		return nil, err
+		return failedStream[*pb.AuditTxInfo](err)
 	}
+	return pumpStream(ctx, cli.metrics, subscriptionType(pb.AuditSubscriptionRequest_AUDIT_NODE), subClient.Recv, decodeMessage[pb.AuditTxInfo])
+}
+
+func (cli *ChainClient) subscribeAudit(ctx context.Context, typ pb.AuditSubscriptionRequest_Type, blockHeight uint64, extra []byte) (pb.ChainBroker_SubscribeAuditInfoClient, error) {
 	req := &pb.AuditSubscriptionRequest{
 		Type:        typ,
-		AuditNodeId: from.String(),
+		AuditNodeId: cli.currentSigner().Address().String(),
 		BlockHeight: blockHeight,
 		Extra:       extra,
 	}
 
-	subClient, err := grpcClient.broker.SubscribeAuditInfo(ctx, req)
+	var stream *auditStream
+	err := cli.openStream(ctx, "SubscribeAuditInfo", func(ctx context.Context, client *grpcClient) error {
+		subClient, err := client.broker.SubscribeAuditInfo(ctx, req)
+		if err != nil {
+			return err
+		}
+		stream = &auditStream{ChainBroker_SubscribeAuditInfoClient: subClient, client: client}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, err
 	}
+	return stream, nil
+}
 
-	c := make(chan interface{})
-	go func() {
-		defer close(c)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				resp, err := subClient.Recv()
-				if err != nil {
-					cli.logger.Error("receive: ", err)
-					return
-				}
-
-				var ret interface{}
-				switch typ {
-				case pb.AuditSubscriptionRequest_AUDIT_NODE:
-					auditInfo := &pb.AuditTxInfo{}
-					if err := auditInfo.Unmarshal(resp.Data); err != nil {
-						cli.logger.Errorf("receive audit info error: %v", err)
-						return
-					}
-					ret = auditInfo
-				default:
-					ret = resp.Data
-				}
-
-				c <- ret
-			}
-		}
-	}()
+// auditStream classifies the errors breaking an audit subscription.
+type auditStream struct {
+	pb.ChainBroker_SubscribeAuditInfoClient
+	client *grpcClient
+}
 
-	return c, nil
+func (s *auditStream) Recv() (*pb.Response, error) {
+	resp, err := s.ChainBroker_SubscribeAuditInfoClient.Recv()
+	if err != nil && err != io.EOF {
+		return nil, s.client.rpcError("SubscribeAuditInfo", err)
+	}
+	return resp, err
 }
@@ -7,10 +7,8 @@ import (
 	"testing"
 
 	"github.com/meshplus/bitxhub-kit/crypto/asym"
-	"github.com/meshplus/bitxhub-model/constant"
 	"github.com/meshplus/bitxhub-model/pb"
 	"github.com/stretchr/testify/require"
-	"github.com/tidwall/gjson"
 )
 
 func TestChainClient_SubscribeAudit(t *testing.T) {
@@ -129,12 +127,9 @@ func prepare(t *testing.T) (*ChainClient, *ChainClient, *ChainClient, *ChainClie
 	)
 	require.Nil(t, err)
 
-	adminAddr1, err := adminCli1.privateKey.PublicKey().Address()
-	require.Nil(t, err)
-	appchainAddr, err := appchainCli.privateKey.PublicKey().Address()
-	require.Nil(t, err)
-	nodeAddr, err := nodeCli.privateKey.PublicKey().Address()
-	require.Nil(t, err)
+	adminAddr1 := adminCli1.currentSigner().Address()
+	appchainAddr := appchainCli.currentSigner().Address()
+	nodeAddr := nodeCli.currentSigner().Address()
 
 	nonce, err := adminCli1.GetPendingNonceByAccount(adminAddr1.String())
 	require.Nil(t, err)
@@ -151,56 +146,44 @@ func prepare(t *testing.T) (*ChainClient, *ChainClient, *ChainClient, *ChainClie
 }
 
 func registerAppchain(t *testing.T, adminCli1, adminCli2, adminCli3, appchainCLi *ChainClient, chainID, chainName, appchainAdmin string) {
-	r, err := appchainCLi.InvokeBVMContract(constant.AppchainMgrContractAddr.Address(), "RegisterAppchain", nil,
-		String(chainID),
-		String(chainName),
-		Bytes(nil),
-		String("ETH"),
-		Bytes(nil),
-		String("broker"),
-		String("des"),
-		String(HappyRuleAddr),
-		String("url"),
-		String(appchainAdmin),
-		String("reason"),
-	)
-	require.Nil(t, err)
-	require.Equal(t, true, r.IsSuccess(), string(r.Ret))
-	proposalId := gjson.Get(string(r.Ret), "proposal_id").String()
-
-	vote(t, adminCli1, adminCli2, adminCli3, proposalId)
+	ret, err := appchainCLi.Governance().RegisterAppchain(context.Background(), &RegisterAppchainRequest{
+		ChainID:        chainID,
+		ChainName:      chainName,
+		ChainType:      "ETH",
+		Broker:         "broker",
+		Desc:           "des",
+		MasterRuleAddr: HappyRuleAddr,
+		MasterRuleURL:  "url",
+		AdminAddrs:     []string{appchainAdmin},
+		Reason:         "reason",
+	}, nil)
+	require.Nil(t, err)
+
+	vote(t, adminCli1, adminCli2, adminCli3, ret.ProposalID)
 }
 
 func registerNode(t *testing.T, adminCli1, adminCli2, adminCli3 *ChainClient, nodeAccount, nodeName, appchainID string) {
-	r, err := adminCli1.InvokeBVMContract(constant.NodeManagerContractAddr.Address(), "RegisterNode", nil,
-		String(nodeAccount),
-		String("nvpNode"),
-		String(""),
-		Uint64(0),
-		String(nodeName),
-		String(appchainID),
-		String("reason"),
-	)
+	ret, err := adminCli1.Governance().RegisterNode(context.Background(), &RegisterNodeRequest{
+		NodeAccount: nodeAccount,
+		NodeType:    "nvpNode",
+		NodeName:    nodeName,
+		Permits:     []string{appchainID},
+		Reason:      "reason",
+	}, nil)
 	require.Nil(t, err)
-	require.Nil(t, err)
-	require.Equal(t, true, r.IsSuccess(), string(r.Ret))
-	proposalId := gjson.Get(string(r.Ret), "proposal_id").String()
 
-	vote(t, adminCli1, adminCli2, adminCli3, proposalId)
+	vote(t, adminCli1, adminCli2, adminCli3, ret.ProposalID)
 }
 
 func updateAppchain(t *testing.T, adminCli1, adminCli2, adminCli3, appchainCLi *ChainClient, chainID, chainName, appchainAdmin string) {
-	r, err := appchainCLi.InvokeBVMContract(constant.AppchainMgrContractAddr.Address(), "UpdateAppchain", nil,
-		String(chainID),
-		String(chainName+"111"),
-		String("desc"),
-		Bytes(nil),
-		String(appchainAdmin),
-		String("reason"),
-	)
+	ret, err := appchainCLi.Governance().UpdateAppchain(context.Background(), &UpdateAppchainRequest{
+		ChainID:    chainID,
+		ChainName:  chainName + "111",
+		Desc:       "desc",
+		AdminAddrs: []string{appchainAdmin},
+		Reason:     "reason",
+	}, nil)
 	require.Nil(t, err)
-	require.Equal(t, true, r.IsSuccess(), string(r.Ret))
-	proposalId := gjson.Get(string(r.Ret), "proposal_id").String()
 
-	vote(t, adminCli1, adminCli2, adminCli3, proposalId)
+	vote(t, adminCli1, adminCli2, adminCli3, ret.ProposalID)
 }
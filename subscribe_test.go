@@ -5,69 +5,85 @@ import (
 	"testing"
 	"time"
 
-	"github.com/meshplus/bitxhub-kit/crypto"
-	"github.com/meshplus/bitxhub-kit/crypto/asym"
 	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestChainClient_Subscribe(t *testing.T) {
-	privKey, err := asym.GenerateKeyPair(crypto.Secp256k1)
-	require.Nil(t, err)
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.SetCommitDelay(200 * time.Millisecond)
 
-	privKey1, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := cli.Subscribe(ctx, pb.SubscriptionRequest_BLOCK, nil)
 	require.Nil(t, err)
 
-	from, err := privKey.PublicKey().Address()
+	hash, err := sendTestTransfer(cli)
 	require.Nil(t, err)
+	require.EqualValues(t, 66, len(hash))
 
-	to, err := privKey1.PublicKey().Address()
-	require.Nil(t, err)
+	select {
+	case block, ok := <-c:
+		require.True(t, ok)
+		require.True(t, blockContainsTx(block.(*pb.Block), hash))
+	case <-ctx.Done():
+		t.Fatal("no block received")
+	}
+}
 
-	cli, err := New(
-		WithNodesInfo(cfg.nodesInfo...),
-		WithLogger(cfg.logger),
-		WithPrivateKey(privKey),
-	)
-	require.Nil(t, err)
+func TestChainClient_SubscribeBlocks(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.SetCommitDelay(200 * time.Millisecond)
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	blocks, errC := cli.SubscribeBlocks(ctx)
 
-	c, err := cli.Subscribe(ctx, pb.SubscriptionRequest_BLOCK, nil)
+	hash, err := sendTestTransfer(cli)
 	require.Nil(t, err)
 
-	td := &pb.TransactionData{
-		Amount: "10",
+	select {
+	case block := <-blocks:
+		require.True(t, blockContainsTx(block, hash))
+	case err := <-errC:
+		t.Fatalf("subscription failed: %v", err)
+	case <-ctx.Done():
+		t.Fatal("no block received")
 	}
-	data, err := td.Marshal()
-	require.Nil(t, err)
 
-	go func() {
-		tx := &pb.BxhTransaction{
-			From:      from,
-			To:        to,
-			Payload:   data,
-			Timestamp: time.Now().UnixNano(),
-		}
-
-		err = tx.Sign(privKey)
-		require.Nil(t, err)
-
-		hash, err := cli.SendTransaction(tx, nil)
-		require.Nil(t, err)
-		require.EqualValues(t, 66, len(hash))
-	}()
-
-	for {
-		select {
-		case block, ok := <-c:
-			require.Equal(t, true, ok)
-			require.NotNil(t, block)
-
-			return
-		case <-ctx.Done():
-			return
-		}
+	// cancelling ends the subscription without an error
+	cancel()
+	for range blocks {
 	}
+	require.Nil(t, <-errC)
+}
+
+func TestChainClient_SubscribeBlocksError(t *testing.T) {
+	broker, cli, _ := newBrokerClient(t, nil)
+	broker.InjectFault("Subscribe", rpcxtest.Fault{Code: codes.Unimplemented})
+
+	blocks, errC := cli.SubscribeBlocks(context.Background())
+	_, ok := <-blocks
+	require.False(t, ok)
+	require.Equal(t, codes.Unimplemented, status.Code(<-errC))
+}
+
+func TestDecodeSubscription(t *testing.T) {
+	header := &pb.BlockHeader{Number: 10}
+	data, err := header.Marshal()
+	require.Nil(t, err)
+
+	ret, err := decodeSubscription(pb.SubscriptionRequest_BLOCK_HEADER, data)
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), ret.(*pb.BlockHeader).Number)
+
+	_, err = decodeSubscription(pb.SubscriptionRequest_BLOCK, []byte{0xff, 0xff})
+	require.NotNil(t, err)
+
+	ret, err = decodeSubscription(pb.SubscriptionRequest_Type(100), []byte("raw"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("raw"), ret)
 }
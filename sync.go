@@ -9,27 +9,20 @@ import (
 )
 
 func (cli *ChainClient) GetBlockHeader(ctx context.Context, begin, end uint64, ch chan<- *pb.BlockHeader) error {
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	grpcClient, err := cli.pool.getClient()
-	if err != nil {
+	var (
+		client     *grpcClient
+		syncClient pb.ChainBroker_GetBlockHeaderClient
+	)
+	err := cli.openStream(ctx, "GetBlockHeader", func(ctx context.Context, c *grpcClient) (err error) {
+		client = c
+		syncClient, err = c.broker.GetBlockHeader(ctx, &pb.GetBlockHeaderRequest{
+			Begin: begin,
+			End:   end,
+		})
 		return err
-	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
-		}
-	}()
-
-	syncClient, err := grpcClient.broker.GetBlockHeader(ctx, &pb.GetBlockHeaderRequest{
-		Begin: begin,
-		End:   end,
 	})
 	if err != nil {
-		return fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return err
 	}
 
 	go func() {
@@ -42,7 +35,7 @@ func (cli *ChainClient) GetBlockHeader(ctx context.Context, begin, end uint64, c
 				resp, err := syncClient.Recv()
 				if err != nil {
 					if err != io.EOF {
-						cli.logger.Error(err)
+						cli.logger.Error(client.rpcError("GetBlockHeader", err))
 					}
 					return
 				}
@@ -55,49 +48,92 @@ func (cli *ChainClient) GetBlockHeader(ctx context.Context, begin, end uint64, c
 	return nil
 }
 
+// GetInterchainTxWrappers sends the interchain tx wrappers of the appchain pid
+// from block begin to end on ch, which is closed when the stream ends. An error
+// ending the stream, like a wrapper failing verification, is only logged, see
+// SyncInterchainTxWrappers to receive it.
 func (cli *ChainClient) GetInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64, ch chan<- *pb.InterchainTxWrappers) error {
-	ctx, err := cli.SetCtxMetadata(ctx)
-	if err != nil {
-		return fmt.Errorf("set ctx metadata err: %v", err)
-	}
-
-	grpcClient, err := cli.pool.getClient()
+	wrappers, errC, err := cli.syncInterchainTxWrappers(ctx, pid, begin, end)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err := grpcClient.conn.Close(); err != nil {
-			cli.logger.Errorf("close conn err: %s", err)
+
+	go func() {
+		defer close(ch)
+		for resp := range wrappers {
+			select {
+			case ch <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := <-errC; err != nil {
+			cli.logger.Error(err)
 		}
 	}()
-	syncClient, err := grpcClient.broker.GetInterchainTxWrappers(ctx, &pb.GetInterchainTxWrappersRequest{
-		Begin: begin,
-		End:   end,
-		Pid:   pid,
+
+	return nil
+}
+
+// SyncInterchainTxWrappers streams the interchain tx wrappers of the appchain
+// pid from block begin to end. Like SubscribeInterchainTxWrappers, an error
+// ending the stream is delivered on the error channel, after which both
+// channels are closed. With WithWrapperVerification, a wrapper failing
+// verification ends the stream with an error wrapping ErrInvalidWrapper.
+func (cli *ChainClient) SyncInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64) (<-chan *pb.InterchainTxWrappers, <-chan error) {
+	wrappers, errC, err := cli.syncInterchainTxWrappers(ctx, pid, begin, end)
+	if err != nil {
+		return failedStream[*pb.InterchainTxWrappers](err)
+	}
+	return wrappers, errC
+}
+
+func (cli *ChainClient) syncInterchainTxWrappers(ctx context.Context, pid string, begin, end uint64) (<-chan *pb.InterchainTxWrappers, <-chan error, error) {
+	var (
+		client     *grpcClient
+		syncClient pb.ChainBroker_GetInterchainTxWrappersClient
+	)
+	err := cli.openStream(ctx, "GetInterchainTxWrappers", func(ctx context.Context, c *grpcClient) (err error) {
+		client = c
+		syncClient, err = c.broker.GetInterchainTxWrappers(ctx, &pb.GetInterchainTxWrappersRequest{
+			Begin: begin,
+			End:   end,
+			Pid:   pid,
+		})
+		return err
 	})
 	if err != nil {
-		return fmt.Errorf("%s, %w", err.Error(), ErrBrokenNetwork)
+		return nil, nil, err
 	}
 
+	c := make(chan *pb.InterchainTxWrappers)
+	errC := make(chan error, 1)
 	go func() {
-		defer close(ch)
+		defer close(errC)
+		defer close(c)
 		for {
-			select {
-			case <-ctx.Done():
+			resp, err := syncClient.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					errC <- client.rpcError("GetInterchainTxWrappers", err)
+				}
 				return
-			default:
-				resp, err := syncClient.Recv()
-				if err != nil {
-					if err != io.EOF {
-						cli.logger.Error(err)
-					}
+			}
+
+			if cli.verifyWrappers {
+				if err := cli.VerifyInterchainTxWrappersCtx(ctx, resp); err != nil {
+					errC <- fmt.Errorf("verify interchain tx wrappers: %w", err)
 					return
 				}
+			}
 
-				ch <- resp
+			select {
+			case c <- resp:
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
 
-	return nil
+	return c, errC, nil
 }
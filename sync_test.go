@@ -2,9 +2,9 @@ package rpcx
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"path/filepath"
 	"testing"
 	"time"
@@ -149,20 +149,9 @@ func prepareKeypair(t *testing.T) (cli *ChainClient, privKey crypto.PrivateKey,
 }
 
 func sendNormal(t *testing.T, cli *ChainClient, from, to *types.Address, _ crypto.PrivateKey) {
-	data := &pb.TransactionData{
-		Amount: "10",
-	}
-
-	payload, err := data.Marshal()
+	tx, err := NewTxBuilder(from).Transfer(to, big.NewInt(10)).Build()
 	require.Nil(t, err)
 
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        to,
-		Payload:   payload,
-		Timestamp: time.Now().UnixNano(),
-	}
-
 	hash, err := cli.SendTransaction(tx, nil)
 	require.Nil(t, err)
 	require.EqualValues(t, 66, len(hash))
@@ -187,20 +176,18 @@ func sendInterchaintx(t *testing.T, cli0 *ChainClient, cli1 *ChainClient) {
 	//srcRawPubKey, err := cli0.privateKey.PublicKey().Bytes()
 	//require.Nil(t, err)
 	//srcPubKey := base64.StdEncoding.EncodeToString(srcRawPubKey)
-	from, err := cli0.privateKey.PublicKey().Address()
+	from := cli0.currentSigner().Address()
 
 	//dstRawPubKey, err := cli1.privateKey.PublicKey().Bytes()
 	//require.Nil(t, err)
 	//dstPubKey := base64.StdEncoding.EncodeToString(dstRawPubKey)
-	to, err := cli1.privateKey.PublicKey().Address()
-	require.Nil(t, err)
+	to := cli1.currentSigner().Address()
 
 	appchain0 := "appchain" + from.String()
 	appchain1 := "appchain" + to.String()
 
 	// register src appchain
-	appchainAdmin, err := cli0.privateKey.PublicKey().Address()
-	require.Nil(t, err)
+	appchainAdmin := cli0.currentSigner().Address()
 	r, err := cli0.InvokeBVMContract(
 		constant.AppchainMgrContractAddr.Address(),
 		"RegisterAppchain", nil,
@@ -224,7 +211,7 @@ func sendInterchaintx(t *testing.T, cli0 *ChainClient, cli1 *ChainClient) {
 	vote(t, adminCli1, adminCli2, adminCli3, proposalId)
 
 	// register dst appchain
-	appchainAdmin1, err := cli1.privateKey.PublicKey().Address()
+	appchainAdmin1 := cli1.currentSigner().Address()
 	r, err = cli1.InvokeBVMContract(
 		constant.AppchainMgrContractAddr.Address(),
 		"RegisterAppchain", nil,
@@ -294,10 +281,12 @@ func sendInterchaintx(t *testing.T, cli0 *ChainClient, cli1 *ChainClient) {
 	// vote for service1 register
 	vote(t, adminCli1, adminCli2, adminCli3, proposalId)
 
-	ibtp := getIBTP(srcServiceID, dstServiceID, 1, pb.IBTP_INTERCHAIN, proof)
-
-	tx, _ := cli0.GenerateIBTPTx(ibtp)
-	tx.Extra = proof
+	tx, err := NewIBTPBuilder(srcServiceID, dstServiceID, 1).
+		Call("interchainCharge", []byte("Alice"), []byte("Alice"), []byte("1")).
+		TimeoutHeight(10).
+		Proof(proof).
+		BuildTx(cli0)
+	require.Nil(t, err)
 	r, err = cli0.SendTransactionWithReceipt(tx, nil)
 	require.Nil(t, err)
 	require.Equal(t, true, r.IsSuccess(), string(r.Ret))
@@ -342,39 +331,13 @@ func deployRule(t *testing.T, cli *ChainClient, appchainID string) string {
 	require.Nil(t, err)
 
 	// register rule
-	ret, err := cli.InvokeBVMContract(constant.RuleManagerContractAddr.Address(),
-		"RegisterRule", nil, pb.String(appchainID), pb.String(contractAddr.String()), pb.String(""))
+	ret, err := cli.Governance().RegisterRule(context.Background(), &RegisterRuleRequest{
+		ChainID:  appchainID,
+		RuleAddr: contractAddr.String(),
+	}, nil)
 	require.Nil(t, err)
-	require.True(t, ret.IsSuccess(), string(ret.Ret))
 
-	return gjson.Get(string(ret.Ret), "proposal_id").String()
-}
-
-func getIBTP(from, to string, index uint64, typ pb.IBTP_Type, proof []byte) *pb.IBTP {
-	content := &pb.Content{
-		Func: "interchainCharge",
-		Args: [][]byte{[]byte("Alice"), []byte("Alice"), []byte("1")},
-	}
-
-	bytes, _ := content.Marshal()
-
-	payload := &pb.Payload{
-		Encrypted: false,
-		Content:   bytes,
-	}
-
-	ibtppd, _ := payload.Marshal()
-	proofHash := sha256.Sum256(proof)
-
-	return &pb.IBTP{
-		From:          from,
-		To:            to,
-		Payload:       ibtppd,
-		Index:         index,
-		Type:          typ,
-		TimeoutHeight: 10,
-		Proof:         proofHash[:],
-	}
+	return ret.ProposalID
 }
 
 // getAdminCli returns client with admin account.
@@ -399,24 +362,11 @@ func getAdminCli(t *testing.T, keyPath string) *ChainClient {
 }
 
 func transfer(t *testing.T, cli *ChainClient, to *types.Address, amount uint64, opt *TransactOpts) {
-	from, err := cli.privateKey.PublicKey().Address()
-	require.Nil(t, err)
-
-	data := &pb.TransactionData{
-		Amount: fmt.Sprintf("%d", amount),
-	}
+	from := cli.currentSigner().Address()
 
-	payload, err := data.Marshal()
+	tx, err := NewTxBuilder(from).Transfer(to, new(big.Int).SetUint64(amount)).Build()
 	require.Nil(t, err)
 
-	tx := &pb.BxhTransaction{
-		From:      from,
-		To:        to,
-		Payload:   payload,
-		Amount:    data.Amount,
-		Timestamp: time.Now().UnixNano(),
-	}
-
 	_, err = cli.SendTransaction(tx, opt)
 	require.Nil(t, err)
 }
@@ -0,0 +1,192 @@
+package rpcx
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// TxBuilder builds a BitXHub transaction doing one of a transfer, a contract
+// deployment, a contract invocation or an interchain IBTP. Like IBTPBuilder, the
+// first invalid step is reported by Build:
+//
+//	tx, err := NewTxBuilder(from).
+//		Transfer(to, big.NewInt(100)).
+//		WithNonce(nonce).
+//		Sign(key).
+//		Build()
+//
+// The timestamp defaults to the time of Build. A transaction built without Sign
+// is left to be signed when sent, as by SendTransaction.
+type TxBuilder struct {
+	tx     *pb.BxhTransaction
+	data   *pb.TransactionData
+	signer Signer
+	err    error
+}
+
+// NewTxBuilder starts a transaction sent from the account from. from may be
+// nil if the transaction is signed by Sign, whose key then gives the sender.
+func NewTxBuilder(from *types.Address) *TxBuilder {
+	return &TxBuilder{tx: &pb.BxhTransaction{From: from}}
+}
+
+func (b *TxBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// setAction makes the transaction do the action built by data or ibtp.
+func (b *TxBuilder) setAction(to *types.Address, data *pb.TransactionData, ibtp *pb.IBTP) {
+	if b.data != nil || b.tx.IBTP != nil {
+		b.setErr(fmt.Errorf("%w: transaction already has an action", ErrReconstruct))
+		return
+	}
+	b.tx.To = to
+	b.data = data
+	b.tx.IBTP = ibtp
+}
+
+// Transfer transfers amount to the account to.
+func (b *TxBuilder) Transfer(to *types.Address, amount *big.Int) *TxBuilder {
+	if to == nil {
+		b.setErr(fmt.Errorf("%w: transfer without receiver", ErrReconstruct))
+		return b
+	}
+	if amount == nil || amount.Sign() <= 0 {
+		b.setErr(fmt.Errorf("%w: transfer amount must be positive", ErrReconstruct))
+		return b
+	}
+	b.setAction(to, &pb.TransactionData{
+		Type:   pb.TransactionData_NORMAL,
+		Amount: amount.String(),
+	}, nil)
+	b.tx.Amount = amount.String()
+	return b
+}
+
+// Deploy deploys the wasm contract.
+func (b *TxBuilder) Deploy(wasm []byte) *TxBuilder {
+	if len(wasm) == 0 {
+		b.setErr(fmt.Errorf("%w: can't deploy empty contract", ErrReconstruct))
+		return b
+	}
+	b.setAction(&types.Address{}, &pb.TransactionData{
+		Type:    pb.TransactionData_INVOKE,
+		VmType:  pb.TransactionData_XVM,
+		Payload: wasm,
+	}, nil)
+	return b
+}
+
+// Invoke invokes method of the contract at address with args.
+func (b *TxBuilder) Invoke(vmType pb.TransactionData_VMType, address *types.Address, method string, args ...*pb.Arg) *TxBuilder {
+	if address == nil || method == "" {
+		b.setErr(fmt.Errorf("%w: invoke without contract address or method", ErrReconstruct))
+		return b
+	}
+	pl := &pb.InvokePayload{
+		Method: method,
+		Args:   args,
+	}
+	data, err := pl.Marshal()
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	b.setAction(address, &pb.TransactionData{
+		Type:    pb.TransactionData_INVOKE,
+		VmType:  vmType,
+		Payload: data,
+	}, nil)
+	return b
+}
+
+// IBTP sends ibtp to the interchain contract.
+func (b *TxBuilder) IBTP(ibtp *pb.IBTP) *TxBuilder {
+	if ibtp == nil {
+		b.setErr(fmt.Errorf("%w: empty ibtp not allowed", ErrReconstruct))
+		return b
+	}
+	b.setAction(constant.InterchainContractAddr.Address(), nil, ibtp)
+	return b
+}
+
+func (b *TxBuilder) WithNonce(nonce uint64) *TxBuilder {
+	b.tx.Nonce = nonce
+	return b
+}
+
+// WithTimestamp sets the timestamp of the transaction in nanoseconds.
+func (b *TxBuilder) WithTimestamp(timestamp int64) *TxBuilder {
+	b.tx.Timestamp = timestamp
+	return b
+}
+
+func (b *TxBuilder) WithExtra(extra []byte) *TxBuilder {
+	b.tx.Extra = extra
+	return b
+}
+
+// Sign signs the built transaction with key. The key must be the one of the
+// sender if it is given to NewTxBuilder.
+func (b *TxBuilder) Sign(key crypto.PrivateKey) *TxBuilder {
+	signer, err := NewKeySigner(key)
+	if err != nil {
+		b.setErr(fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error()))
+		return b
+	}
+	return b.SignWith(signer)
+}
+
+// SignWith signs the built transaction with signer, as Sign does with a key.
+func (b *TxBuilder) SignWith(signer Signer) *TxBuilder {
+	b.signer = signer
+	return b
+}
+
+// Build checks the transaction and returns it, signed if Sign or SignWith is
+// called.
+func (b *TxBuilder) Build() (*pb.BxhTransaction, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.data == nil && b.tx.IBTP == nil {
+		return nil, fmt.Errorf("%w: transaction has no action", ErrReconstruct)
+	}
+
+	tx := *b.tx
+	if b.signer != nil {
+		addr := b.signer.Address()
+		if tx.From == nil {
+			tx.From = addr
+		} else if tx.From.String() != addr.String() {
+			return nil, fmt.Errorf("%w: signing key of %s for transaction from %s", ErrSignTx, addr, tx.From)
+		}
+	}
+	if tx.From == nil {
+		return nil, fmt.Errorf("%w: from address can't be empty", ErrReconstruct)
+	}
+	if b.data != nil {
+		payload, err := b.data.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		tx.Payload = payload
+	}
+	if tx.Timestamp == 0 {
+		tx.Timestamp = time.Now().UnixNano()
+	}
+	if b.signer != nil {
+		if err := signTx(&tx, b.signer); err != nil {
+			return nil, fmt.Errorf("%w: for reason %s", ErrSignTx, err.Error())
+		}
+	}
+	return &tx, nil
+}
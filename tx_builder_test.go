@@ -0,0 +1,81 @@
+package rpcx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/meshplus/bitxhub-kit/crypto"
+	"github.com/meshplus/bitxhub-kit/crypto/asym"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/constant"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxBuilder(t *testing.T) {
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	from, err := key.PublicKey().Address()
+	require.Nil(t, err)
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+
+	tx, err := NewTxBuilder(nil).
+		Transfer(to, big.NewInt(100)).
+		WithNonce(3).
+		WithTimestamp(42).
+		WithExtra([]byte("extra")).
+		Sign(key).
+		Build()
+	require.Nil(t, err)
+	require.Equal(t, from.String(), tx.From.String())
+	require.Equal(t, "100", tx.Amount)
+	require.Equal(t, uint64(3), tx.Nonce)
+	require.Equal(t, int64(42), tx.Timestamp)
+	require.Equal(t, []byte("extra"), tx.Extra)
+	require.Nil(t, tx.VerifySignature())
+	td := &pb.TransactionData{}
+	require.Nil(t, td.Unmarshal(tx.Payload))
+	require.Equal(t, pb.TransactionData_NORMAL, td.Type)
+	require.Equal(t, "100", td.Amount)
+
+	tx, err = NewTxBuilder(from).Deploy([]byte("wasm")).Build()
+	require.Nil(t, err)
+	require.Equal(t, (&types.Address{}).String(), tx.To.String())
+	require.NotZero(t, tx.Timestamp)
+	require.Nil(t, tx.Signature)
+
+	tx, err = NewTxBuilder(from).Invoke(pb.TransactionData_BVM, constant.StoreContractAddr.Address(), "Set", String("a"), String("10")).Build()
+	require.Nil(t, err)
+	method, args := decodeInvoke(t, tx)
+	require.Equal(t, "Set", method)
+	require.Len(t, args, 2)
+
+	ibtp := &pb.IBTP{From: testFromService, To: testToService, Index: 1}
+	tx, err = NewTxBuilder(from).IBTP(ibtp).Build()
+	require.Nil(t, err)
+	require.Equal(t, constant.InterchainContractAddr.Address().String(), tx.To.String())
+	require.Equal(t, ibtp, tx.IBTP)
+}
+
+func TestTxBuilder_Invalid(t *testing.T) {
+	key, err := asym.GenerateKeyPair(crypto.Secp256k1)
+	require.Nil(t, err)
+	to := types.NewAddressByStr("0x0000000000000000000000000000000000000001")
+
+	for name, builder := range map[string]*TxBuilder{
+		"no action":   NewTxBuilder(to),
+		"no from":     NewTxBuilder(nil).Transfer(to, big.NewInt(1)),
+		"two actions": NewTxBuilder(to).Transfer(to, big.NewInt(1)).Deploy([]byte("wasm")),
+		"zero amount": NewTxBuilder(to).Transfer(to, big.NewInt(0)),
+		"no receiver": NewTxBuilder(to).Transfer(nil, big.NewInt(1)),
+		"empty wasm":  NewTxBuilder(to).Deploy(nil),
+		"no method":   NewTxBuilder(to).Invoke(pb.TransactionData_BVM, to, ""),
+		"empty ibtp":  NewTxBuilder(to).IBTP(nil),
+	} {
+		_, err := builder.Build()
+		require.ErrorIs(t, err, ErrReconstruct, name)
+	}
+
+	_, err = NewTxBuilder(to).Transfer(to, big.NewInt(1)).Sign(key).Build()
+	require.ErrorIs(t, err, ErrSignTx)
+}
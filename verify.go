@@ -0,0 +1,182 @@
+package rpcx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/cbergoon/merkletree"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+)
+
+// ErrInvalidWrapper is returned when an interchain tx wrapper doesn't match
+// the block header it claims to belong to.
+var ErrInvalidWrapper = errors.New("invalid interchain tx wrapper")
+
+// VerifyInterchainTxWrapper checks the wrapper against the header of its block:
+// the L2 roots must build the TxRoot of the header, and the verified
+// transactions must build one of the L2 roots. The timeout L2 roots are checked
+// against the TimeoutRoot of the header in the same way, with the hashes of the
+// timeout IBTP IDs as leaves. The header commits to no multi-tx IBTP, so every
+// one of them must be the IBTP of a verified transaction.
+func VerifyInterchainTxWrapper(wrapper *pb.InterchainTxWrapper, header *pb.BlockHeader) error {
+	if wrapper == nil || header == nil {
+		return fmt.Errorf("%w: wrapper or block header is nil", ErrInvalidWrapper)
+	}
+	if wrapper.Height != header.Number {
+		return fmt.Errorf("%w: wrapper height %d doesn't match block header %d", ErrInvalidWrapper, wrapper.Height, header.Number)
+	}
+
+	if len(wrapper.Transactions) != 0 {
+		if len(wrapper.L2Roots) == 0 {
+			return fmt.Errorf("%w: no l2 roots for %d transactions in block %d", ErrInvalidWrapper, len(wrapper.Transactions), wrapper.Height)
+		}
+		if err := verifyMerkleRoot(wrapper.L2Roots, header.TxRoot); err != nil {
+			return fmt.Errorf("%w: l2 roots of block %d: %s", ErrInvalidWrapper, wrapper.Height, err)
+		}
+
+		txs := make([]merkletree.Content, 0, len(wrapper.Transactions))
+		for i, tx := range wrapper.Transactions {
+			if tx == nil || tx.Tx == nil {
+				return fmt.Errorf("%w: transaction %d in block %d is empty", ErrInvalidWrapper, i, wrapper.Height)
+			}
+			txs = append(txs, tx)
+		}
+		root, err := merkleRoot(txs)
+		if err != nil {
+			return fmt.Errorf("%w: build transaction tree of block %d: %s", ErrInvalidWrapper, wrapper.Height, err)
+		}
+		if !containsRoot(wrapper.L2Roots, root) {
+			return fmt.Errorf("%w: transactions of block %d are not under any l2 root", ErrInvalidWrapper, wrapper.Height)
+		}
+	}
+
+	if len(wrapper.TimeoutIbtps) != 0 {
+		if len(wrapper.TimeoutL2Roots) == 0 {
+			return fmt.Errorf("%w: no timeout l2 roots for %d timeout ibtps in block %d", ErrInvalidWrapper, len(wrapper.TimeoutIbtps), wrapper.Height)
+		}
+		if err := verifyMerkleRoot(wrapper.TimeoutL2Roots, header.TimeoutRoot); err != nil {
+			return fmt.Errorf("%w: timeout l2 roots of block %d: %s", ErrInvalidWrapper, wrapper.Height, err)
+		}
+
+		root, err := timeoutRoot(wrapper.TimeoutIbtps)
+		if err != nil {
+			return fmt.Errorf("%w: build timeout ibtp tree of block %d: %s", ErrInvalidWrapper, wrapper.Height, err)
+		}
+		if !containsRoot(wrapper.TimeoutL2Roots, root) {
+			return fmt.Errorf("%w: timeout ibtps of block %d are not under any timeout l2 root", ErrInvalidWrapper, wrapper.Height)
+		}
+	}
+
+	if len(wrapper.MultiTxIbtps) != 0 {
+		ids := make(map[string]struct{}, len(wrapper.Transactions))
+		for _, tx := range wrapper.Transactions {
+			if tx.Tx.IBTP != nil {
+				ids[tx.Tx.IBTP.ID()] = struct{}{}
+			}
+		}
+		for _, id := range wrapper.MultiTxIbtps {
+			if _, ok := ids[id]; !ok {
+				return fmt.Errorf("%w: multi-tx ibtp %s of block %d is not a verified transaction", ErrInvalidWrapper, id, wrapper.Height)
+			}
+		}
+	}
+
+	return nil
+}
+
+// timeoutRoot builds the merkle root of the IDs of timeout IBTPs the way
+// BitXHub builds a timeout L2 root, with the sha256 hash of each ID as a leaf.
+func timeoutRoot(ids []string) ([]byte, error) {
+	contents := make([]merkletree.Content, 0, len(ids))
+	for _, id := range ids {
+		hash := sha256.Sum256([]byte(id))
+		contents = append(contents, types.NewHash(hash[:]))
+	}
+	return merkleRoot(contents)
+}
+
+// VerifyInterchainTxWrappers verifies every wrapper against the block header of
+// its height, which is queried from BitXHub with GetBlockHeader.
+func (cli *ChainClient) VerifyInterchainTxWrappers(wrappers *pb.InterchainTxWrappers) error {
+	return cli.VerifyInterchainTxWrappersCtx(context.Background(), wrappers)
+}
+
+func (cli *ChainClient) VerifyInterchainTxWrappersCtx(ctx context.Context, wrappers *pb.InterchainTxWrappers) error {
+	if wrappers == nil {
+		return fmt.Errorf("%w: wrappers is nil", ErrInvalidWrapper)
+	}
+
+	headers := make(map[uint64]*pb.BlockHeader)
+	for _, wrapper := range wrappers.InterchainTxWrappers {
+		if wrapper == nil {
+			return fmt.Errorf("%w: wrapper is nil", ErrInvalidWrapper)
+		}
+		header, ok := headers[wrapper.Height]
+		if !ok {
+			var err error
+			header, err = cli.blockHeaderAt(ctx, wrapper.Height)
+			if err != nil {
+				return fmt.Errorf("get block header %d to verify wrapper: %w", wrapper.Height, err)
+			}
+			headers[wrapper.Height] = header
+		}
+		if err := VerifyInterchainTxWrapper(wrapper, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cli *ChainClient) blockHeaderAt(ctx context.Context, height uint64) (*pb.BlockHeader, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan *pb.BlockHeader, 1)
+	if err := cli.GetBlockHeader(ctx, height, height, ch); err != nil {
+		return nil, err
+	}
+	header, ok := <-ch
+	if !ok || header.Number != height {
+		return nil, fmt.Errorf("block header %d is not found", height)
+	}
+	return header, nil
+}
+
+func verifyMerkleRoot(hashes []types.Hash, expected *types.Hash) error {
+	if expected == nil {
+		return fmt.Errorf("block header has no root")
+	}
+	contents := make([]merkletree.Content, 0, len(hashes))
+	for i := range hashes {
+		contents = append(contents, &hashes[i])
+	}
+	root, err := merkleRoot(contents)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, expected.Bytes()) {
+		return fmt.Errorf("merkle root %x doesn't match %s", root, expected.String())
+	}
+	return nil
+}
+
+func merkleRoot(contents []merkletree.Content) ([]byte, error) {
+	tree, err := merkletree.NewTree(contents)
+	if err != nil {
+		return nil, err
+	}
+	return tree.MerkleRoot(), nil
+}
+
+func containsRoot(roots []types.Hash, root []byte) bool {
+	for i := range roots {
+		if bytes.Equal(roots[i].Bytes(), root) {
+			return true
+		}
+	}
+	return false
+}
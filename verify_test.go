@@ -0,0 +1,164 @@
+package rpcx
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cbergoon/merkletree"
+	"github.com/meshplus/bitxhub-kit/types"
+	"github.com/meshplus/bitxhub-model/pb"
+	"github.com/meshplus/go-bitxhub-client/rpcxtest"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWrapper builds a wrapper of n transactions and two timeout IBTPs at
+// height and the header it belongs to, whose TxRoot and TimeoutRoot also cover
+// those of another appchain. The IBTP of the first transaction is a multi-tx
+// IBTP.
+func newTestWrapper(t *testing.T, height uint64, n int) (*pb.InterchainTxWrapper, *pb.BlockHeader) {
+	var txs []*pb.VerifiedTx
+	for i := 0; i < n; i++ {
+		txs = append(txs, &pb.VerifiedTx{
+			Tx:    &pb.BxhTransaction{Nonce: uint64(i), Timestamp: time.Now().UnixNano()},
+			Valid: i%2 == 0,
+		})
+	}
+	txs[0].Tx.IBTP = &pb.IBTP{From: testFromService, To: testToService, Index: 1}
+	contents := make([]merkletree.Content, 0, len(txs))
+	for _, tx := range txs {
+		contents = append(contents, tx)
+	}
+	root, err := merkleRoot(contents)
+	require.Nil(t, err)
+
+	l2Roots := []types.Hash{*types.NewHash(root), *types.NewHash([]byte("other appchain"))}
+	l2Contents := []merkletree.Content{&l2Roots[0], &l2Roots[1]}
+	txRoot, err := merkleRoot(l2Contents)
+	require.Nil(t, err)
+
+	timeoutIBTPs := []string{testFromService + "-" + testToService + "-2", testFromService + "-" + testToService + "-3"}
+	root, err = timeoutRoot(timeoutIBTPs)
+	require.Nil(t, err)
+	timeoutL2Roots := []types.Hash{*types.NewHash(root), *types.NewHash([]byte("other appchain"))}
+	timeoutContents := []merkletree.Content{&timeoutL2Roots[0], &timeoutL2Roots[1]}
+	headerTimeoutRoot, err := merkleRoot(timeoutContents)
+	require.Nil(t, err)
+
+	wrapper := &pb.InterchainTxWrapper{
+		L2Roots:        l2Roots,
+		Transactions:   txs,
+		Height:         height,
+		TimeoutL2Roots: timeoutL2Roots,
+		TimeoutIbtps:   timeoutIBTPs,
+		MultiTxIbtps:   []string{txs[0].Tx.IBTP.ID()},
+	}
+	header := &pb.BlockHeader{
+		Number:      height,
+		TxRoot:      types.NewHash(txRoot),
+		TimeoutRoot: types.NewHash(headerTimeoutRoot),
+	}
+	return wrapper, header
+}
+
+func TestVerifyInterchainTxWrapper(t *testing.T) {
+	wrapper, header := newTestWrapper(t, 10, 3)
+	require.Nil(t, VerifyInterchainTxWrapper(wrapper, header))
+
+	// a block without interchain txs has nothing to prove
+	require.Nil(t, VerifyInterchainTxWrapper(&pb.InterchainTxWrapper{Height: 10}, header))
+
+	for name, tamper := range map[string]func(w *pb.InterchainTxWrapper){
+		"flipped valid":     func(w *pb.InterchainTxWrapper) { w.Transactions[0].Valid = !w.Transactions[0].Valid },
+		"missing tx":        func(w *pb.InterchainTxWrapper) { w.Transactions = w.Transactions[1:] },
+		"empty tx":          func(w *pb.InterchainTxWrapper) { w.Transactions[1] = &pb.VerifiedTx{} },
+		"replaced tx":       func(w *pb.InterchainTxWrapper) { w.Transactions[2].Tx = &pb.BxhTransaction{Nonce: 100} },
+		"wrong height":      func(w *pb.InterchainTxWrapper) { w.Height = 11 },
+		"no l2 roots":       func(w *pb.InterchainTxWrapper) { w.L2Roots = nil },
+		"forged l2 root":    func(w *pb.InterchainTxWrapper) { w.L2Roots[1] = *types.NewHash([]byte("forged")) },
+		"timeout unproved":  func(w *pb.InterchainTxWrapper) { w.TimeoutIbtps = []string{"1:chain0:service0-1:chain1:service1-1"} },
+		"dropped l2 root":   func(w *pb.InterchainTxWrapper) { w.L2Roots = w.L2Roots[:1] },
+		"timeout no header": func(w *pb.InterchainTxWrapper) { w.TimeoutL2Roots, w.TimeoutIbtps = w.L2Roots, []string{"id"} },
+		"tampered timeout":  func(w *pb.InterchainTxWrapper) { w.TimeoutIbtps[0] = testFromService + "-" + testToService + "-9" },
+		"dropped timeout":   func(w *pb.InterchainTxWrapper) { w.TimeoutIbtps = w.TimeoutIbtps[1:] },
+		"no timeout roots":  func(w *pb.InterchainTxWrapper) { w.TimeoutL2Roots = nil },
+		"forged multi-tx":   func(w *pb.InterchainTxWrapper) { w.MultiTxIbtps[0] = testFromService + "-" + testToService + "-9" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			wrapper, header := newTestWrapper(t, 10, 3)
+			tamper(wrapper)
+			require.ErrorIs(t, VerifyInterchainTxWrapper(wrapper, header), ErrInvalidWrapper)
+		})
+	}
+}
+
+// forgingBroker flips the validity of the first tx of the interchain tx
+// wrappers it serves while forge is set.
+type forgingBroker struct {
+	*rpcxtest.Broker
+	forge int32
+}
+
+func (b *forgingBroker) GetInterchainTxWrappers(req *pb.GetInterchainTxWrappersRequest, srv pb.ChainBroker_GetInterchainTxWrappersServer) error {
+	if atomic.LoadInt32(&b.forge) == 0 {
+		return b.Broker.GetInterchainTxWrappers(req, srv)
+	}
+	return b.Broker.GetInterchainTxWrappers(req, &forgingStream{srv})
+}
+
+type forgingStream struct {
+	pb.ChainBroker_GetInterchainTxWrappersServer
+}
+
+func (s *forgingStream) Send(wrappers *pb.InterchainTxWrappers) error {
+	forged := &pb.InterchainTxWrappers{}
+	for _, wrapper := range wrappers.InterchainTxWrappers {
+		copied := *wrapper
+		if len(copied.Transactions) != 0 {
+			copied.Transactions = append([]*pb.VerifiedTx{}, copied.Transactions...)
+			tx := *copied.Transactions[0]
+			tx.Valid = !tx.Valid
+			copied.Transactions[0] = &tx
+		}
+		forged.InterchainTxWrappers = append(forged.InterchainTxWrappers, &copied)
+	}
+	return s.ChainBroker_GetInterchainTxWrappersServer.Send(forged)
+}
+
+func TestChainClient_WrapperVerification(t *testing.T) {
+	broker := &forgingBroker{Broker: rpcxtest.NewBroker()}
+	require.Nil(t, broker.StartWith(broker))
+	defer broker.Stop()
+	_, cli, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker}, WithWrapperVerification())
+	_, unverified, _ := newBrokerClient(t, []*rpcxtest.Broker{broker.Broker})
+	ctx := context.Background()
+
+	_, err := cli.SendIBTPCtx(ctx, NewIBTPBuilder(testFromService, testToService, 1).Call("f"), nil)
+	require.Nil(t, err)
+	height := broker.Height()
+
+	sync := func(cli *ChainClient) []*pb.InterchainTxWrappers {
+		ch := make(chan *pb.InterchainTxWrappers, 1)
+		require.Nil(t, cli.GetInterchainTxWrappers(ctx, "appchain2", height, height, ch))
+		var ret []*pb.InterchainTxWrappers
+		for wrappers := range ch {
+			ret = append(ret, wrappers)
+		}
+		return ret
+	}
+
+	require.Len(t, sync(cli), 1)
+
+	atomic.StoreInt32(&broker.forge, 1)
+	require.Len(t, sync(cli), 0)
+	wrappers, errC := cli.SyncInterchainTxWrappers(ctx, "appchain2", height, height)
+	for range wrappers {
+		t.Fatal("forged interchain tx wrappers are delivered")
+	}
+	require.ErrorIs(t, <-errC, ErrInvalidWrapper)
+	forged := sync(unverified)
+	require.Len(t, forged, 1)
+	err = cli.VerifyInterchainTxWrappersCtx(ctx, forged[0])
+	require.ErrorIs(t, err, ErrInvalidWrapper)
+}